@@ -23,6 +23,30 @@
 //	clientOpts := options.Client().ApplyURI("mongodb://localhost:27017").SetMonitor(cmdMonitor)
 //	client, err := mongo.Connect(context.Background(), clientOpts)
 //
+// Unlike a CommandMonitor, a CommandInterceptor wraps the execution of each
+// operation and can change the command, reject it or inspect its result.
+// Interceptors are set with SetInterceptors when constructing a mongo.Client.
+// The following code adds a comment to every command and measures how long
+// each operation takes:
+//
+//	tagger := func(ctx context.Context, cmd *event.InterceptedCommand, next event.CommandHandler) error {
+//	  var doc bson.D
+//	  if err := bson.Unmarshal(cmd.Command, &doc); err != nil {
+//	    return err
+//	  }
+//	  raw, err := bson.Marshal(append(doc, bson.E{"comment", "tenant-42"}))
+//	  if err != nil {
+//	    return err
+//	  }
+//	  cmd.Command = raw
+//	  start := time.Now()
+//	  err = next(ctx, cmd)
+//	  log.Printf("%s took %v", cmd.CommandName, time.Since(start))
+//	  return err
+//	}
+//	clientOpts := options.Client().ApplyURI("mongodb://localhost:27017").SetInterceptors(tagger)
+//	client, err := mongo.Connect(context.Background(), clientOpts)
+//
 // Monitoring the connection pool requires specifying a PoolMonitor when constructing
 // a mongo.Client. The following code tracks the number of checked out connections:
 //
@@ -0,0 +1,46 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package event
+
+import (
+	"context"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+)
+
+// InterceptedCommand describes a command that is about to be executed by an operation. It is passed to each
+// CommandInterceptor in a chain.
+type InterceptedCommand struct {
+	// DatabaseName is the name of the database the command runs against. Changes to this field are ignored.
+	DatabaseName string
+
+	// CommandName is the name of the command. Changes to this field are ignored.
+	CommandName string
+
+	// Command contains the elements of the command built by the operation. It does not contain the fields that the
+	// driver appends to every command, such as lsid, $db, $clusterTime, readConcern, writeConcern and maxTimeMS, or
+	// the documents of batched writes. An interceptor can replace Command before calling the next handler to add,
+	// change or remove fields. The first element must remain the command name. If Command contains a maxTimeMS field,
+	// the driver does not append its own.
+	Command bson.Raw
+
+	// Reply is the server response to the last command sent for the operation. It is set when the next handler
+	// returns, unless no response was received.
+	Reply bson.Raw
+}
+
+// CommandHandler executes an intercepted command.
+type CommandHandler func(ctx context.Context, cmd *InterceptedCommand) error
+
+// CommandInterceptor wraps the execution of an operation. It must call next to continue the execution, and can
+// inspect or modify cmd before doing so, return an error without calling next to reject the operation, or inspect the
+// reply and the error returned by next.
+//
+// An operation can send more than one command to the server, for example when a write is split into batches or
+// retried. The interceptor chain runs once per operation, after a server has been selected for the first attempt, and
+// the changes made to cmd apply to each command sent.
+type CommandInterceptor func(ctx context.Context, cmd *InterceptedCommand, next CommandHandler) error
@@ -79,6 +79,26 @@ type CommandMonitor struct {
 	Failed    func(context.Context, *CommandFailedEvent)
 }
 
+// TransactionRetryEvent represents an event generated when Session.WithTransaction retries a transaction or the commit
+// of a transaction.
+type TransactionRetryEvent struct {
+	// SessionID is the ID document of the session running the transaction.
+	SessionID bson.Raw
+	// Attempt is the number of the attempt that failed, starting at 1.
+	Attempt int
+	// Err is the error that caused the retry.
+	Err error
+	// CommitRetry is true if only the commit is retried and false if the whole transaction is retried.
+	CommitRetry bool
+	// Delay is the amount of time waited before the retry.
+	Delay time.Duration
+}
+
+// TransactionMonitor represents a monitor that is triggered for transaction events.
+type TransactionMonitor struct {
+	Retry func(context.Context, *TransactionRetryEvent)
+}
+
 // strings for pool command monitoring reasons
 const (
 	ReasonIdle              = "idle"
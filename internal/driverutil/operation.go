@@ -11,6 +11,8 @@ package driverutil
 const (
 	AbortTransactionOp  = "abortTransaction"  // AbortTransactionOp is the name for aborting a transaction
 	AggregateOp         = "aggregate"         // AggregateOp is the name for aggregating
+	BulkWriteOp         = "bulkWrite"         // BulkWriteOp is the name for client-level bulk writes
+	CollModOp           = "collMod"           // CollModOp is the name for modifying a collection
 	CommitTransactionOp = "commitTransaction" // CommitTransactionOp is the name for committing a transaction
 	CountOp             = "count"             // CountOp is the name for counting
 	CreateOp            = "create"            // CreateOp is the name for creating
@@ -21,11 +23,13 @@ const (
 	DropDatabaseOp      = "dropDatabase"      // DropDatabaseOp is the name for dropping a database
 	DropIndexesOp       = "dropIndexes"       // DropIndexesOp is the name for dropping indexes
 	EndSessionsOp       = "endSessions"       // EndSessionsOp is the name for ending sessions
+	ExplainOp           = "explain"           // ExplainOp is the name for explaining
 	FindAndModifyOp     = "findAndModify"     // FindAndModifyOp is the name for finding and modifying
 	FindOp              = "find"              // FindOp is the name for finding
 	InsertOp            = "insert"            // InsertOp is the name for inserting
 	ListCollectionsOp   = "listCollections"   // ListCollectionsOp is the name for listing collections
 	ListIndexesOp       = "listIndexes"       // ListIndexesOp is the name for listing indexes
 	ListDatabasesOp     = "listDatabases"     // ListDatabasesOp is the name for listing databases
+	RenameCollectionOp  = "renameCollection"  // RenameCollectionOp is the name for renaming a collection
 	UpdateOp            = "update"            // UpdateOp is the name for updating
 )
@@ -0,0 +1,43 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package builder provides builders for query filter and update documents and aggregation pipelines, so that
+// operators and stages are spelled by the compiler instead of being typed as strings in bson.D literals.
+//
+// A filter is built with a FilterBuilder:
+//
+//	filter, err := builder.Filter().
+//		Gte("age", 18).
+//		Lt("age", 65).
+//		In("status", "active", "pending").
+//		Build()
+//
+// and an update with an UpdateBuilder:
+//
+//	ub := builder.Update().
+//		Set("grades.$[g].passed", true).
+//		Inc("version", 1).
+//		ArrayFilter("g", builder.ElementFilter().Gte("score", 60))
+//	update, err := ub.Build()
+//	...
+//	res, err := coll.UpdateMany(ctx, filter, update, options.Update().SetArrayFilters(ub.ArrayFilters()))
+//
+// Aggregation pipelines are built with a PipelineBuilder, which rejects invalid stage ordering such as a $merge that
+// is not the last stage:
+//
+//	pipeline, err := builder.Pipeline().
+//		Match(builder.Filter().Eq("status", "shipped")).
+//		Group("$customerId", builder.Sum("total", "$amount"), builder.CountDocuments("orders")).
+//		Merge("customerTotals", nil).
+//		Build()
+//
+// Field paths can be checked against a Go struct type by creating the builders with a Schema. The field names are
+// resolved the same way as by the StructCodec, using a bsoncodec.StructTagParser:
+//
+//	schema, err := builder.SchemaFor[User](nil)
+//	...
+//	filter, err := builder.FilterFor(schema).Eq("emial", addr).Build() // returns an error: User has no field "emial"
+package builder
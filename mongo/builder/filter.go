@@ -0,0 +1,369 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/bsontype"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/primitive"
+)
+
+// FilterBuilder builds a query filter document. Conditions added for the same field are combined into a single
+// operator document, e.g. Gte("age", 18).Lt("age", 65) builds {age: {$gte: 18, $lt: 65}}. Conditions on different
+// fields are implicitly ANDed by the server.
+//
+// If the builder was created with a Schema, every field path is validated against it. The first error encountered is
+// returned by Build and all later calls are no-ops.
+type FilterBuilder struct {
+	schema  *Schema
+	element bool
+	doc     bson.D
+	err     error
+}
+
+// Filter creates a new FilterBuilder that does not validate field paths.
+func Filter() *FilterBuilder {
+	return &FilterBuilder{}
+}
+
+// FilterFor creates a new FilterBuilder that validates field paths against schema.
+func FilterFor(schema *Schema) *FilterBuilder {
+	return &FilterBuilder{schema: schema}
+}
+
+// ElementFilter creates a new FilterBuilder for conditions on the elements of an array, for use with
+// FilterBuilder.ElemMatch and UpdateBuilder.ArrayFilter. Field paths are relative to the element, and the empty path
+// refers to the element itself, e.g. ElementFilter().Gte("", 80) matches elements that are at least 80.
+func ElementFilter() *FilterBuilder {
+	return &FilterBuilder{element: true}
+}
+
+// ElementFilterFor is like ElementFilter but validates non-empty field paths against schema, which should describe the
+// array element type.
+func ElementFilterFor(schema *Schema) *FilterBuilder {
+	return &FilterBuilder{schema: schema, element: true}
+}
+
+// Build returns the filter document or the first error encountered while building it. An empty builder returns an
+// empty document, which matches all documents.
+func (fb *FilterBuilder) Build() (bson.D, error) {
+	if fb.err != nil {
+		return nil, fb.err
+	}
+	if fb.doc == nil {
+		return bson.D{}, nil
+	}
+	return fb.doc, nil
+}
+
+// Eq adds a condition matching documents where field is equal to value.
+func (fb *FilterBuilder) Eq(field string, value interface{}) *FilterBuilder {
+	return fb.op(field, "$eq", value)
+}
+
+// Ne adds a condition matching documents where field is not equal to value.
+func (fb *FilterBuilder) Ne(field string, value interface{}) *FilterBuilder {
+	return fb.op(field, "$ne", value)
+}
+
+// Gt adds a condition matching documents where field is greater than value.
+func (fb *FilterBuilder) Gt(field string, value interface{}) *FilterBuilder {
+	return fb.op(field, "$gt", value)
+}
+
+// Gte adds a condition matching documents where field is greater than or equal to value.
+func (fb *FilterBuilder) Gte(field string, value interface{}) *FilterBuilder {
+	return fb.op(field, "$gte", value)
+}
+
+// Lt adds a condition matching documents where field is less than value.
+func (fb *FilterBuilder) Lt(field string, value interface{}) *FilterBuilder {
+	return fb.op(field, "$lt", value)
+}
+
+// Lte adds a condition matching documents where field is less than or equal to value.
+func (fb *FilterBuilder) Lte(field string, value interface{}) *FilterBuilder {
+	return fb.op(field, "$lte", value)
+}
+
+// In adds a condition matching documents where field is equal to any of values.
+func (fb *FilterBuilder) In(field string, values ...interface{}) *FilterBuilder {
+	return fb.op(field, "$in", bson.A(values))
+}
+
+// Nin adds a condition matching documents where field is not equal to any of values or does not exist.
+func (fb *FilterBuilder) Nin(field string, values ...interface{}) *FilterBuilder {
+	return fb.op(field, "$nin", bson.A(values))
+}
+
+// Exists adds a condition matching documents that contain field if exists is true, or that do not contain it if
+// exists is false.
+func (fb *FilterBuilder) Exists(field string, exists bool) *FilterBuilder {
+	return fb.op(field, "$exists", exists)
+}
+
+// Type adds a condition matching documents where field is of any of the given BSON types. At least one type must be
+// provided.
+func (fb *FilterBuilder) Type(field string, types ...bsontype.Type) *FilterBuilder {
+	if len(types) == 0 {
+		return fb.fail(fmt.Errorf("$type condition on field %q requires at least one type", field))
+	}
+	if len(types) == 1 {
+		return fb.op(field, "$type", int32(types[0]))
+	}
+	arr := make(bson.A, 0, len(types))
+	for _, t := range types {
+		arr = append(arr, int32(t))
+	}
+	return fb.op(field, "$type", arr)
+}
+
+// All adds a condition matching documents where the array field contains all of values.
+func (fb *FilterBuilder) All(field string, values ...interface{}) *FilterBuilder {
+	return fb.op(field, "$all", bson.A(values))
+}
+
+// ElemMatch adds a condition matching documents where the array field contains at least one element matching all of
+// the conditions in cond, which should be created with ElementFilter or ElementFilterFor.
+func (fb *FilterBuilder) ElemMatch(field string, cond *FilterBuilder) *FilterBuilder {
+	doc, err := cond.Build()
+	if err != nil {
+		return fb.fail(err)
+	}
+
+	return fb.op(field, "$elemMatch", elementCondition(doc))
+}
+
+// Size adds a condition matching documents where the array field has exactly size elements.
+func (fb *FilterBuilder) Size(field string, size int32) *FilterBuilder {
+	return fb.op(field, "$size", size)
+}
+
+// Regex adds a condition matching documents where field matches the regular expression pattern with the given
+// options (e.g. "i" for case-insensitive matching).
+func (fb *FilterBuilder) Regex(field, pattern, options string) *FilterBuilder {
+	return fb.op(field, "$regex", primitive.Regex{Pattern: pattern, Options: options})
+}
+
+// GeoWithin adds a condition matching documents where the geospatial field lies entirely within geometry, which must
+// be a GeoJSON Polygon or MultiPolygon.
+func (fb *FilterBuilder) GeoWithin(field string, geometry interface{}) *FilterBuilder {
+	return fb.op(field, "$geoWithin", bson.D{{"$geometry", geometry}})
+}
+
+// GeoIntersects adds a condition matching documents where the geospatial field intersects geometry, which must be a
+// GeoJSON object.
+func (fb *FilterBuilder) GeoIntersects(field string, geometry interface{}) *FilterBuilder {
+	return fb.op(field, "$geoIntersects", bson.D{{"$geometry", geometry}})
+}
+
+// Near adds a condition returning documents ordered from nearest to farthest from point, which must be a GeoJSON
+// Point. The minDistance and maxDistance parameters are in meters and are omitted from the query if they are not
+// positive.
+func (fb *FilterBuilder) Near(field string, point interface{}, minDistance, maxDistance float64) *FilterBuilder {
+	return fb.op(field, "$near", nearDocument(point, minDistance, maxDistance))
+}
+
+// NearSphere is like Near but calculates distances using spherical geometry.
+func (fb *FilterBuilder) NearSphere(field string, point interface{}, minDistance, maxDistance float64) *FilterBuilder {
+	return fb.op(field, "$nearSphere", nearDocument(point, minDistance, maxDistance))
+}
+
+// Point returns a GeoJSON Point with the given longitude and latitude.
+func Point(longitude, latitude float64) bson.D {
+	return bson.D{{"type", "Point"}, {"coordinates", bson.A{longitude, latitude}}}
+}
+
+// Polygon returns a GeoJSON Polygon with the given rings. Each ring is a closed list of [longitude, latitude] pairs.
+func Polygon(rings ...[][2]float64) bson.D {
+	coords := make(bson.A, 0, len(rings))
+	for _, ring := range rings {
+		points := make(bson.A, 0, len(ring))
+		for _, p := range ring {
+			points = append(points, bson.A{p[0], p[1]})
+		}
+		coords = append(coords, points)
+	}
+	return bson.D{{"type", "Polygon"}, {"coordinates", coords}}
+}
+
+// Expr adds a $expr condition, which allows the use of aggregation expressions in the query.
+func (fb *FilterBuilder) Expr(expression interface{}) *FilterBuilder {
+	return fb.top("$expr", expression)
+}
+
+// And adds a condition matching documents that match all of filters.
+func (fb *FilterBuilder) And(filters ...*FilterBuilder) *FilterBuilder {
+	return fb.logical("$and", filters)
+}
+
+// Or adds a condition matching documents that match at least one of filters. If Or is called more than once, documents
+// must match at least one of the filters of each call.
+func (fb *FilterBuilder) Or(filters ...*FilterBuilder) *FilterBuilder {
+	return fb.logical("$or", filters)
+}
+
+// Nor adds a condition matching documents that match none of filters.
+func (fb *FilterBuilder) Nor(filters ...*FilterBuilder) *FilterBuilder {
+	return fb.logical("$nor", filters)
+}
+
+// Not adds the negation of each field condition in cond, e.g. Not(Filter().Gt("price", 10)) builds
+// {price: {$not: {$gt: 10}}}. Documents that do not contain the field also match. The conditions in cond must be
+// field conditions; top-level operators such as $and or $expr cannot be negated with $not.
+func (fb *FilterBuilder) Not(cond *FilterBuilder) *FilterBuilder {
+	doc, err := cond.Build()
+	if err != nil {
+		return fb.fail(err)
+	}
+	for _, elem := range doc {
+		if len(elem.Key) > 0 && elem.Key[0] == '$' {
+			return fb.fail(fmt.Errorf("%s cannot be negated with $not", elem.Key))
+		}
+		value := elem.Value
+		// $not takes a regular expression directly rather than a $regex operator document.
+		if ops, ok := value.(bson.D); ok && hasKey(ops, "$regex") {
+			if len(ops) > 1 {
+				return fb.fail(fmt.Errorf("$regex on %s cannot be negated together with other operators", elem.Key))
+			}
+			value = ops[0].Value
+		}
+		fb.op(elem.Key, "$not", value)
+	}
+	return fb
+}
+
+// op adds the condition {field: {operator: value}}, merging it into an existing operator document for field if there
+// is one.
+func (fb *FilterBuilder) op(field, operator string, value interface{}) *FilterBuilder {
+	if fb.err != nil {
+		return fb
+	}
+	if !fb.element || field != "" {
+		if err := validateField(fb.schema, field); err != nil {
+			return fb.fail(err)
+		}
+	}
+
+	for i, elem := range fb.doc {
+		if elem.Key != field {
+			continue
+		}
+		ops, ok := elem.Value.(bson.D)
+		if !ok {
+			break
+		}
+		fb.doc[i].Value = append(ops, bson.E{operator, value})
+		return fb
+	}
+	fb.doc = append(fb.doc, bson.E{field, bson.D{{operator, value}}})
+	return fb
+}
+
+// top adds the top-level element {key: value}.
+func (fb *FilterBuilder) top(key string, value interface{}) *FilterBuilder {
+	if fb.err != nil {
+		return fb
+	}
+	fb.doc = append(fb.doc, bson.E{key, value})
+	return fb
+}
+
+func (fb *FilterBuilder) logical(operator string, filters []*FilterBuilder) *FilterBuilder {
+	if len(filters) == 0 {
+		return fb.fail(fmt.Errorf("%s requires at least one filter", operator))
+	}
+	arr := make(bson.A, 0, len(filters))
+	for _, f := range filters {
+		if f == nil {
+			return fb.fail(fmt.Errorf("%s filters must not be nil", operator))
+		}
+		doc, err := f.Build()
+		if err != nil {
+			return fb.fail(err)
+		}
+		arr = append(arr, doc)
+	}
+
+	// A document cannot have two $or keys, and merging two $or arrays would require only one of the conditions of
+	// both to match, so a second $or is added to $and. The conditions of $and and $nor can be merged.
+	if operator == "$or" && hasKey(fb.doc, "$or") {
+		return fb.appendTop("$and", bson.A{bson.D{{"$or", arr}}})
+	}
+	return fb.appendTop(operator, arr)
+}
+
+// appendTop appends values to the array of the top-level element key, adding the element if there is none.
+func (fb *FilterBuilder) appendTop(key string, values bson.A) *FilterBuilder {
+	if fb.err != nil {
+		return fb
+	}
+	for i, elem := range fb.doc {
+		if arr, ok := elem.Value.(bson.A); ok && elem.Key == key {
+			fb.doc[i].Value = append(arr, values...)
+			return fb
+		}
+	}
+	return fb.top(key, values)
+}
+
+// hasKey returns true if doc has an element with key.
+func hasKey(doc bson.D, key string) bool {
+	for _, elem := range doc {
+		if elem.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (fb *FilterBuilder) fail(err error) *FilterBuilder {
+	if fb.err == nil {
+		fb.err = err
+	}
+	return fb
+}
+
+// elementCondition converts a document built by an ElementFilter into the form used by $elemMatch and $pull, where
+// conditions on the element itself are written as top-level operators.
+func elementCondition(doc bson.D) bson.D {
+	cond := make(bson.D, 0, len(doc))
+	for _, elem := range doc {
+		if elem.Key != "" {
+			cond = append(cond, elem)
+			continue
+		}
+		if ops, ok := elem.Value.(bson.D); ok {
+			cond = append(cond, ops...)
+		}
+	}
+	return cond
+}
+
+func nearDocument(point interface{}, minDistance, maxDistance float64) bson.D {
+	doc := bson.D{{"$geometry", point}}
+	if minDistance > 0 {
+		doc = append(doc, bson.E{"$minDistance", minDistance})
+	}
+	if maxDistance > 0 {
+		doc = append(doc, bson.E{"$maxDistance", maxDistance})
+	}
+	return doc
+}
+
+func validateField(schema *Schema, field string) error {
+	if field == "" {
+		return errors.New("field path must not be empty")
+	}
+	if schema == nil {
+		return nil
+	}
+	return schema.Validate(field)
+}
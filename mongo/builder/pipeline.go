@@ -0,0 +1,767 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo"
+)
+
+// Stages that must be the last stage of a pipeline.
+var finalStages = map[string]bool{
+	"$out":   true,
+	"$merge": true,
+}
+
+// Stages that are not allowed in the sub-pipelines of $facet.
+var facetDisallowedStages = map[string]bool{
+	"$collStats":      true,
+	"$facet":          true,
+	"$geoNear":        true,
+	"$indexStats":     true,
+	"$out":            true,
+	"$merge":          true,
+	"$planCacheStats": true,
+}
+
+// Stages that are allowed in a change stream pipeline.
+var changeStreamStages = map[string]bool{
+	"$addFields":                   true,
+	"$match":                       true,
+	"$project":                     true,
+	"$replaceRoot":                 true,
+	"$replaceWith":                 true,
+	"$redact":                      true,
+	"$set":                         true,
+	"$unset":                       true,
+	"$changeStreamSplitLargeEvent": true,
+}
+
+// PipelineBuilder builds an aggregation pipeline. Stages are appended in the order the methods are called.
+//
+// Stage ordering rules that the server would otherwise reject are checked by Build: $out and $merge must be the last
+// stage, sub-pipelines of $lookup, $unionWith and $facet cannot contain $out or $merge, and $facet sub-pipelines cannot
+// contain the other stages $facet disallows. The first error encountered is returned by Build and all later calls are
+// no-ops.
+type PipelineBuilder struct {
+	stages []bson.D
+	err    error
+}
+
+// Pipeline creates a new, empty PipelineBuilder.
+func Pipeline() *PipelineBuilder {
+	return &PipelineBuilder{}
+}
+
+// Build returns the pipeline or the first error encountered while building it. The result can be passed to
+// Collection.Aggregate, Database.Aggregate and Watch.
+func (pb *PipelineBuilder) Build() (mongo.Pipeline, error) {
+	if pb.err != nil {
+		return nil, pb.err
+	}
+	for i, stage := range pb.stages {
+		name := stage[0].Key
+		if finalStages[name] && i != len(pb.stages)-1 {
+			return nil, fmt.Errorf("%s must be the last stage in the pipeline, but is stage %d of %d", name, i+1,
+				len(pb.stages))
+		}
+	}
+	return mongo.Pipeline(pb.stages), nil
+}
+
+// BuildChangeStream is like Build but also checks that every stage can be used in a change stream pipeline passed to
+// Watch.
+func (pb *PipelineBuilder) BuildChangeStream() (mongo.Pipeline, error) {
+	p, err := pb.Build()
+	if err != nil {
+		return nil, err
+	}
+	for _, stage := range p {
+		if !changeStreamStages[stage[0].Key] {
+			return nil, fmt.Errorf("%s is not allowed in a change stream pipeline", stage[0].Key)
+		}
+	}
+	return p, nil
+}
+
+// Stage appends a stage that has no dedicated method. The stage name must start with "$".
+func (pb *PipelineBuilder) Stage(name string, spec interface{}) *PipelineBuilder {
+	if len(name) < 2 || name[0] != '$' {
+		return pb.fail(fmt.Errorf("invalid stage name %q", name))
+	}
+	return pb.add(name, spec)
+}
+
+// Match appends a $match stage with the given filter.
+func (pb *PipelineBuilder) Match(filter *FilterBuilder) *PipelineBuilder {
+	doc, err := filter.Build()
+	if err != nil {
+		return pb.fail(err)
+	}
+	return pb.add("$match", doc)
+}
+
+// Project appends a $project stage.
+func (pb *PipelineBuilder) Project(projection *Projection) *PipelineBuilder {
+	if len(projection.doc) == 0 {
+		return pb.fail(errors.New("$project requires at least one field"))
+	}
+	return pb.add("$project", projection.doc)
+}
+
+// AddFields appends an $addFields stage that sets each field in fields to the result of its expression.
+func (pb *PipelineBuilder) AddFields(fields bson.D) *PipelineBuilder {
+	if len(fields) == 0 {
+		return pb.fail(errors.New("$addFields requires at least one field"))
+	}
+	return pb.add("$addFields", fields)
+}
+
+// ReplaceRoot appends a $replaceRoot stage that promotes the document produced by newRoot to the top level.
+func (pb *PipelineBuilder) ReplaceRoot(newRoot interface{}) *PipelineBuilder {
+	return pb.add("$replaceRoot", bson.D{{"newRoot", newRoot}})
+}
+
+// Sort appends a $sort stage.
+func (pb *PipelineBuilder) Sort(sort bson.D) *PipelineBuilder {
+	if len(sort) == 0 {
+		return pb.fail(errors.New("$sort requires at least one field"))
+	}
+	return pb.add("$sort", sort)
+}
+
+// Skip appends a $skip stage.
+func (pb *PipelineBuilder) Skip(n int64) *PipelineBuilder {
+	if n < 0 {
+		return pb.fail(fmt.Errorf("$skip must not be negative, but was %d", n))
+	}
+	return pb.add("$skip", n)
+}
+
+// Limit appends a $limit stage.
+func (pb *PipelineBuilder) Limit(n int64) *PipelineBuilder {
+	if n <= 0 {
+		return pb.fail(fmt.Errorf("$limit must be positive, but was %d", n))
+	}
+	return pb.add("$limit", n)
+}
+
+// Count appends a $count stage that outputs a single document with the number of input documents in field.
+func (pb *PipelineBuilder) Count(field string) *PipelineBuilder {
+	if field == "" {
+		return pb.fail(errors.New("$count requires a field name"))
+	}
+	return pb.add("$count", field)
+}
+
+// Group appends a $group stage that groups documents by the id expression and computes the given accumulators. Use a
+// nil id to group all documents together.
+func (pb *PipelineBuilder) Group(id interface{}, accumulators ...Accumulator) *PipelineBuilder {
+	doc := bson.D{{"_id", id}}
+	for _, acc := range accumulators {
+		if acc.window != nil {
+			return pb.fail(fmt.Errorf("accumulator for field %q: windows can only be used in $setWindowFields",
+				acc.field))
+		}
+		e, err := acc.element()
+		if err != nil {
+			return pb.fail(err)
+		}
+		doc = append(doc, e)
+	}
+	return pb.add("$group", doc)
+}
+
+// Lookup appends a $lookup stage that performs an equality match between localField and the foreignField of the
+// documents in the from collection and stores the matches in the array field as.
+func (pb *PipelineBuilder) Lookup(from, localField, foreignField, as string) *PipelineBuilder {
+	if from == "" || localField == "" || foreignField == "" || as == "" {
+		return pb.fail(errors.New("$lookup requires from, localField, foreignField and as"))
+	}
+	return pb.add("$lookup", bson.D{
+		{"from", from},
+		{"localField", localField},
+		{"foreignField", foreignField},
+		{"as", as},
+	})
+}
+
+// LookupPipeline appends a $lookup stage that runs pipeline on the from collection and stores the results in the
+// array field as. The let parameter defines variables usable in the pipeline and may be nil. If from is empty, the
+// pipeline must start with a stage that does not need an input collection, such as $documents.
+func (pb *PipelineBuilder) LookupPipeline(from string, let bson.D, pipeline *PipelineBuilder, as string) *PipelineBuilder {
+	if as == "" {
+		return pb.fail(errors.New("$lookup requires as"))
+	}
+	sub, err := pb.subPipeline("$lookup", pipeline, finalStages)
+	if err != nil {
+		return pb.fail(err)
+	}
+
+	doc := bson.D{}
+	if from != "" {
+		doc = append(doc, bson.E{"from", from})
+	}
+	if len(let) > 0 {
+		doc = append(doc, bson.E{"let", let})
+	}
+	doc = append(doc, bson.E{"pipeline", sub}, bson.E{"as", as})
+	return pb.add("$lookup", doc)
+}
+
+// UnwindOptions represents options that can be used to configure an $unwind stage.
+type UnwindOptions struct {
+	// The name of a field to hold the array index of the element.
+	IncludeArrayIndex string
+
+	// If true, documents whose array is null, missing or empty are output instead of being dropped.
+	PreserveNullAndEmptyArrays bool
+}
+
+// Unwind appends an $unwind stage that outputs one document for each element of the array at path. The path must
+// start with "$". The opts parameter may be nil.
+func (pb *PipelineBuilder) Unwind(path string, opts *UnwindOptions) *PipelineBuilder {
+	if len(path) < 2 || path[0] != '$' {
+		return pb.fail(fmt.Errorf("$unwind path must start with \"$\", but was %q", path))
+	}
+	if opts == nil {
+		return pb.add("$unwind", path)
+	}
+
+	doc := bson.D{{"path", path}}
+	if opts.IncludeArrayIndex != "" {
+		doc = append(doc, bson.E{"includeArrayIndex", opts.IncludeArrayIndex})
+	}
+	if opts.PreserveNullAndEmptyArrays {
+		doc = append(doc, bson.E{"preserveNullAndEmptyArrays", true})
+	}
+	return pb.add("$unwind", doc)
+}
+
+// Facet is a named sub-pipeline of a $facet stage.
+type Facet struct {
+	Name     string
+	Pipeline *PipelineBuilder
+}
+
+// Facet appends a $facet stage that runs each of facets on the same input documents.
+func (pb *PipelineBuilder) Facet(facets ...Facet) *PipelineBuilder {
+	if len(facets) == 0 {
+		return pb.fail(errors.New("$facet requires at least one facet"))
+	}
+	doc := make(bson.D, 0, len(facets))
+	for _, f := range facets {
+		if f.Name == "" {
+			return pb.fail(errors.New("$facet names must not be empty"))
+		}
+		sub, err := pb.subPipeline("$facet", f.Pipeline, facetDisallowedStages)
+		if err != nil {
+			return pb.fail(err)
+		}
+		doc = append(doc, bson.E{f.Name, sub})
+	}
+	return pb.add("$facet", doc)
+}
+
+// BucketOptions represents options that can be used to configure a $bucket stage.
+type BucketOptions struct {
+	// The _id of the bucket for documents whose groupBy value falls outside of the boundaries. If nil, such documents
+	// cause the operation to fail.
+	Default interface{}
+
+	// The fields of each output document. If empty, each document contains a count field.
+	Output []Accumulator
+}
+
+// Bucket appends a $bucket stage that groups documents into buckets by the groupBy expression. The boundaries must
+// contain at least two values in ascending order. The opts parameter may be nil.
+func (pb *PipelineBuilder) Bucket(groupBy interface{}, boundaries []interface{}, opts *BucketOptions) *PipelineBuilder {
+	if len(boundaries) < 2 {
+		return pb.fail(errors.New("$bucket requires at least two boundaries"))
+	}
+
+	doc := bson.D{{"groupBy", groupBy}, {"boundaries", bson.A(boundaries)}}
+	if opts != nil {
+		if opts.Default != nil {
+			doc = append(doc, bson.E{"default", opts.Default})
+		}
+		if len(opts.Output) > 0 {
+			output := make(bson.D, 0, len(opts.Output))
+			for _, acc := range opts.Output {
+				e, err := acc.element()
+				if err != nil {
+					return pb.fail(err)
+				}
+				output = append(output, e)
+			}
+			doc = append(doc, bson.E{"output", output})
+		}
+	}
+	return pb.add("$bucket", doc)
+}
+
+// SetWindowFields appends a $setWindowFields stage. The partitionBy expression and sortBy document may be nil. Each
+// output accumulator may have a window set with Accumulator.Window.
+func (pb *PipelineBuilder) SetWindowFields(partitionBy interface{}, sortBy bson.D, output ...Accumulator) *PipelineBuilder {
+	if len(output) == 0 {
+		return pb.fail(errors.New("$setWindowFields requires at least one output field"))
+	}
+
+	doc := bson.D{}
+	if partitionBy != nil {
+		doc = append(doc, bson.E{"partitionBy", partitionBy})
+	}
+	if len(sortBy) > 0 {
+		doc = append(doc, bson.E{"sortBy", sortBy})
+	}
+	out := make(bson.D, 0, len(output))
+	for _, acc := range output {
+		e, err := acc.element()
+		if err != nil {
+			return pb.fail(err)
+		}
+		out = append(out, e)
+	}
+	doc = append(doc, bson.E{"output", out})
+	return pb.add("$setWindowFields", doc)
+}
+
+// MergeOptions represents options that can be used to configure a $merge stage.
+type MergeOptions struct {
+	// The database of the output collection. Defaults to the database of the aggregation.
+	DB string
+
+	// The fields that identify a document in the output collection. Defaults to _id.
+	On []string
+
+	// Variables usable in a WhenMatched pipeline.
+	Let bson.D
+
+	// The action to take when a result document matches a document in the output collection: "replace",
+	// "keepExisting", "merge", "fail", or a mongo.Pipeline to update the existing document.
+	WhenMatched interface{}
+
+	// The action to take when a result document does not match a document in the output collection: "insert",
+	// "discard" or "fail".
+	WhenNotMatched string
+}
+
+// Merge appends a $merge stage that writes the results to the collection into. It must be the last stage of the
+// pipeline. The opts parameter may be nil.
+func (pb *PipelineBuilder) Merge(into string, opts *MergeOptions) *PipelineBuilder {
+	if into == "" {
+		return pb.fail(errors.New("$merge requires an output collection"))
+	}
+
+	var target interface{} = into
+	doc := bson.D{}
+	if opts != nil {
+		if opts.DB != "" {
+			target = bson.D{{"db", opts.DB}, {"coll", into}}
+		}
+		switch len(opts.On) {
+		case 0:
+		case 1:
+			doc = append(doc, bson.E{"on", opts.On[0]})
+		default:
+			on := make(bson.A, 0, len(opts.On))
+			for _, f := range opts.On {
+				on = append(on, f)
+			}
+			doc = append(doc, bson.E{"on", on})
+		}
+		if len(opts.Let) > 0 {
+			doc = append(doc, bson.E{"let", opts.Let})
+		}
+		if opts.WhenMatched != nil {
+			doc = append(doc, bson.E{"whenMatched", opts.WhenMatched})
+		}
+		if opts.WhenNotMatched != "" {
+			doc = append(doc, bson.E{"whenNotMatched", opts.WhenNotMatched})
+		}
+	}
+	doc = append(bson.D{{"into", target}}, doc...)
+	return pb.add("$merge", doc)
+}
+
+// Out appends an $out stage that writes the results to the collection coll, replacing it if it exists. It must be the
+// last stage of the pipeline.
+func (pb *PipelineBuilder) Out(coll string) *PipelineBuilder {
+	if coll == "" {
+		return pb.fail(errors.New("$out requires an output collection"))
+	}
+	return pb.add("$out", coll)
+}
+
+// OutDB is like Out but writes to the collection coll in the database db.
+func (pb *PipelineBuilder) OutDB(db, coll string) *PipelineBuilder {
+	if db == "" || coll == "" {
+		return pb.fail(errors.New("$out requires an output database and collection"))
+	}
+	return pb.add("$out", bson.D{{"db", db}, {"coll", coll}})
+}
+
+// UnionWith appends a $unionWith stage that adds the documents of the collection coll, optionally processed by
+// pipeline, to the results. The pipeline parameter may be nil.
+func (pb *PipelineBuilder) UnionWith(coll string, pipeline *PipelineBuilder) *PipelineBuilder {
+	if coll == "" {
+		return pb.fail(errors.New("$unionWith requires a collection"))
+	}
+	if pipeline == nil {
+		return pb.add("$unionWith", coll)
+	}
+	sub, err := pb.subPipeline("$unionWith", pipeline, finalStages)
+	if err != nil {
+		return pb.fail(err)
+	}
+	return pb.add("$unionWith", bson.D{{"coll", coll}, {"pipeline", sub}})
+}
+
+// GraphLookupOptions represents options that can be used to configure a $graphLookup stage.
+type GraphLookupOptions struct {
+	// The maximum recursion depth.
+	MaxDepth *int64
+
+	// The name of a field added to each found document that contains its recursion depth.
+	DepthField string
+
+	// Additional conditions that documents must match to be traversed.
+	RestrictSearchWithMatch *FilterBuilder
+}
+
+// GraphLookup appends a $graphLookup stage that recursively searches the from collection, starting with the value of
+// the startWith expression and matching connectFromField of each found document against connectToField, and stores the
+// found documents in the array field as. The opts parameter may be nil.
+func (pb *PipelineBuilder) GraphLookup(from string, startWith interface{}, connectFromField, connectToField, as string,
+	opts *GraphLookupOptions) *PipelineBuilder {
+
+	if from == "" || connectFromField == "" || connectToField == "" || as == "" {
+		return pb.fail(errors.New("$graphLookup requires from, connectFromField, connectToField and as"))
+	}
+
+	doc := bson.D{
+		{"from", from},
+		{"startWith", startWith},
+		{"connectFromField", connectFromField},
+		{"connectToField", connectToField},
+		{"as", as},
+	}
+	if opts != nil {
+		if opts.MaxDepth != nil {
+			if *opts.MaxDepth < 0 {
+				return pb.fail(fmt.Errorf("$graphLookup maxDepth must not be negative, but was %d", *opts.MaxDepth))
+			}
+			doc = append(doc, bson.E{"maxDepth", *opts.MaxDepth})
+		}
+		if opts.DepthField != "" {
+			doc = append(doc, bson.E{"depthField", opts.DepthField})
+		}
+		if opts.RestrictSearchWithMatch != nil {
+			match, err := opts.RestrictSearchWithMatch.Build()
+			if err != nil {
+				return pb.fail(err)
+			}
+			doc = append(doc, bson.E{"restrictSearchWithMatch", match})
+		}
+	}
+	return pb.add("$graphLookup", doc)
+}
+
+// DensifyRange specifies the range of a $densify stage.
+type DensifyRange struct {
+	// The amount to increment the field value by. Required.
+	Step interface{}
+
+	// The unit of Step for date fields, e.g. "hour" or "day". Must be empty for numeric fields.
+	Unit string
+
+	// Either "full", "partition", or a two-element array with the lower and upper bounds.
+	Bounds interface{}
+}
+
+// Densify appends a $densify stage that creates new documents to fill gaps in the values of field. The documents are
+// created within each partition defined by partitionByFields.
+func (pb *PipelineBuilder) Densify(field string, rng DensifyRange, partitionByFields ...string) *PipelineBuilder {
+	if field == "" {
+		return pb.fail(errors.New("$densify requires a field"))
+	}
+	if rng.Step == nil || rng.Bounds == nil {
+		return pb.fail(errors.New("$densify range requires step and bounds"))
+	}
+
+	r := bson.D{{"step", rng.Step}}
+	if rng.Unit != "" {
+		r = append(r, bson.E{"unit", rng.Unit})
+	}
+	r = append(r, bson.E{"bounds", rng.Bounds})
+
+	doc := bson.D{{"field", field}}
+	if len(partitionByFields) > 0 {
+		fields := make(bson.A, 0, len(partitionByFields))
+		for _, f := range partitionByFields {
+			fields = append(fields, f)
+		}
+		doc = append(doc, bson.E{"partitionByFields", fields})
+	}
+	doc = append(doc, bson.E{"range", r})
+	return pb.add("$densify", doc)
+}
+
+// FillOutput specifies how a $fill stage populates one field. Exactly one of Value and Method must be set.
+type FillOutput struct {
+	Field string
+
+	// An expression whose result is used as the fill value.
+	Value interface{}
+
+	// The fill method, either "linear" or "locf".
+	Method string
+}
+
+// FillOptions represents options that can be used to configure a $fill stage.
+type FillOptions struct {
+	// An expression that groups the documents. Cannot be used together with PartitionByFields.
+	PartitionBy interface{}
+
+	// The fields that group the documents. Cannot be used together with PartitionBy.
+	PartitionByFields []string
+
+	// The sort order of the documents within each partition. Required when a fill method is used.
+	SortBy bson.D
+}
+
+// Fill appends a $fill stage that populates null and missing values of the output fields. The opts parameter may be
+// nil.
+func (pb *PipelineBuilder) Fill(opts *FillOptions, output ...FillOutput) *PipelineBuilder {
+	if len(output) == 0 {
+		return pb.fail(errors.New("$fill requires at least one output field"))
+	}
+
+	doc := bson.D{}
+	usesMethod := false
+	out := make(bson.D, 0, len(output))
+	for _, o := range output {
+		if o.Field == "" {
+			return pb.fail(errors.New("$fill output fields must not be empty"))
+		}
+		switch {
+		case o.Value != nil && o.Method != "":
+			return pb.fail(fmt.Errorf("$fill output %q cannot set both value and method", o.Field))
+		case o.Value != nil:
+			out = append(out, bson.E{o.Field, bson.D{{"value", o.Value}}})
+		case o.Method == "linear" || o.Method == "locf":
+			usesMethod = true
+			out = append(out, bson.E{o.Field, bson.D{{"method", o.Method}}})
+		default:
+			return pb.fail(fmt.Errorf("$fill output %q requires a value or a method of \"linear\" or \"locf\"",
+				o.Field))
+		}
+	}
+
+	if opts != nil {
+		if opts.PartitionBy != nil && len(opts.PartitionByFields) > 0 {
+			return pb.fail(errors.New("$fill cannot set both partitionBy and partitionByFields"))
+		}
+		if opts.PartitionBy != nil {
+			doc = append(doc, bson.E{"partitionBy", opts.PartitionBy})
+		}
+		if len(opts.PartitionByFields) > 0 {
+			fields := make(bson.A, 0, len(opts.PartitionByFields))
+			for _, f := range opts.PartitionByFields {
+				fields = append(fields, f)
+			}
+			doc = append(doc, bson.E{"partitionByFields", fields})
+		}
+		if len(opts.SortBy) > 0 {
+			doc = append(doc, bson.E{"sortBy", opts.SortBy})
+		}
+	}
+	if usesMethod && (opts == nil || len(opts.SortBy) == 0) {
+		return pb.fail(errors.New("$fill requires sortBy when a fill method is used"))
+	}
+	doc = append(doc, bson.E{"output", out})
+	return pb.add("$fill", doc)
+}
+
+func (pb *PipelineBuilder) add(name string, spec interface{}) *PipelineBuilder {
+	if pb.err != nil {
+		return pb
+	}
+	pb.stages = append(pb.stages, bson.D{{name, spec}})
+	return pb
+}
+
+func (pb *PipelineBuilder) fail(err error) *PipelineBuilder {
+	if pb.err == nil {
+		pb.err = err
+	}
+	return pb
+}
+
+// subPipeline builds the sub-pipeline of the stage parent and checks that it does not contain any of the disallowed
+// stages.
+func (pb *PipelineBuilder) subPipeline(parent string, sub *PipelineBuilder, disallowed map[string]bool) (mongo.Pipeline, error) {
+	if sub == nil {
+		return mongo.Pipeline{}, nil
+	}
+	p, err := sub.Build()
+	if err != nil {
+		return nil, err
+	}
+	for _, stage := range p {
+		if disallowed[stage[0].Key] {
+			return nil, fmt.Errorf("%s cannot be used in a %s sub-pipeline", stage[0].Key, parent)
+		}
+	}
+	return p, nil
+}
+
+// Projection builds the specification of a $project stage.
+type Projection struct {
+	doc bson.D
+}
+
+// Project creates a new, empty Projection.
+func Project() *Projection {
+	return &Projection{}
+}
+
+// Include includes fields in the output documents.
+func (p *Projection) Include(fields ...string) *Projection {
+	for _, f := range fields {
+		p.doc = append(p.doc, bson.E{f, 1})
+	}
+	return p
+}
+
+// Exclude excludes fields from the output documents. Apart from _id, fields cannot be both included and excluded in
+// the same projection.
+func (p *Projection) Exclude(fields ...string) *Projection {
+	for _, f := range fields {
+		p.doc = append(p.doc, bson.E{f, 0})
+	}
+	return p
+}
+
+// Compute sets field in the output documents to the result of expression.
+func (p *Projection) Compute(field string, expression interface{}) *Projection {
+	p.doc = append(p.doc, bson.E{field, expression})
+	return p
+}
+
+// Window specifies the window of a $setWindowFields output field. Exactly one of Documents and Range must be set.
+type Window struct {
+	// The lower and upper bounds of the window as positions relative to the current document, or "unbounded" or
+	// "current".
+	Documents *[2]interface{}
+
+	// The lower and upper bounds of the window as values of the sortBy field relative to the current document, or
+	// "unbounded" or "current".
+	Range *[2]interface{}
+
+	// The unit of Range for date fields, e.g. "hour" or "day".
+	Unit string
+}
+
+// Accumulator is an output field of a $group, $bucket or $setWindowFields stage that is computed with an accumulator
+// or window operator.
+type Accumulator struct {
+	field    string
+	operator string
+	expr     interface{}
+	window   *Window
+}
+
+// Accumulate creates an Accumulator that sets field to the result of the given accumulator or window operator (e.g.
+// "$stdDevPop" or "$rank") applied to expression.
+func Accumulate(field, operator string, expression interface{}) Accumulator {
+	return Accumulator{field: field, operator: operator, expr: expression}
+}
+
+// Sum creates an Accumulator that sets field to the sum of expression.
+func Sum(field string, expression interface{}) Accumulator {
+	return Accumulate(field, "$sum", expression)
+}
+
+// Avg creates an Accumulator that sets field to the average of expression.
+func Avg(field string, expression interface{}) Accumulator {
+	return Accumulate(field, "$avg", expression)
+}
+
+// Min creates an Accumulator that sets field to the minimum of expression.
+func Min(field string, expression interface{}) Accumulator {
+	return Accumulate(field, "$min", expression)
+}
+
+// Max creates an Accumulator that sets field to the maximum of expression.
+func Max(field string, expression interface{}) Accumulator {
+	return Accumulate(field, "$max", expression)
+}
+
+// First creates an Accumulator that sets field to the value of expression for the first document.
+func First(field string, expression interface{}) Accumulator {
+	return Accumulate(field, "$first", expression)
+}
+
+// Last creates an Accumulator that sets field to the value of expression for the last document.
+func Last(field string, expression interface{}) Accumulator {
+	return Accumulate(field, "$last", expression)
+}
+
+// PushValue creates an Accumulator that sets field to an array of the values of expression.
+func PushValue(field string, expression interface{}) Accumulator {
+	return Accumulate(field, "$push", expression)
+}
+
+// AddToSet creates an Accumulator that sets field to an array of the unique values of expression.
+func AddToSet(field string, expression interface{}) Accumulator {
+	return Accumulate(field, "$addToSet", expression)
+}
+
+// CountDocuments creates an Accumulator that sets field to the number of documents.
+func CountDocuments(field string) Accumulator {
+	return Accumulate(field, "$count", bson.D{})
+}
+
+// Window returns a copy of the Accumulator that is computed over window. Windows can only be used in
+// $setWindowFields.
+func (a Accumulator) Window(window Window) Accumulator {
+	a.window = &window
+	return a
+}
+
+func (a Accumulator) element() (bson.E, error) {
+	if a.field == "" {
+		return bson.E{}, errors.New("accumulator field must not be empty")
+	}
+	if len(a.operator) < 2 || a.operator[0] != '$' {
+		return bson.E{}, fmt.Errorf("invalid accumulator operator %q for field %q", a.operator, a.field)
+	}
+
+	doc := bson.D{{a.operator, a.expr}}
+	if a.window != nil {
+		w := bson.D{}
+		switch {
+		case a.window.Documents != nil && a.window.Range != nil:
+			return bson.E{}, fmt.Errorf("window for field %q cannot set both documents and range", a.field)
+		case a.window.Documents != nil:
+			w = append(w, bson.E{"documents", bson.A{a.window.Documents[0], a.window.Documents[1]}})
+		case a.window.Range != nil:
+			w = append(w, bson.E{"range", bson.A{a.window.Range[0], a.window.Range[1]}})
+		default:
+			return bson.E{}, fmt.Errorf("window for field %q requires documents or range", a.field)
+		}
+		if a.window.Unit != "" {
+			w = append(w, bson.E{"unit", a.window.Unit})
+		}
+		doc = append(doc, bson.E{"window", w})
+	}
+	return bson.E{a.field, doc}, nil
+}
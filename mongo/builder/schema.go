@@ -0,0 +1,227 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/bsoncodec"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/primitive"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+)
+
+var (
+	tMarshaler      = reflect.TypeOf((*bson.Marshaler)(nil)).Elem()
+	tValueMarshaler = reflect.TypeOf((*bson.ValueMarshaler)(nil)).Elem()
+	tD              = reflect.TypeOf(primitive.D{})
+	tRaw            = reflect.TypeOf(bson.Raw(nil))
+	tDocument       = reflect.TypeOf(bsoncore.Document(nil))
+)
+
+// Schema describes the field names of a Go struct type as they appear in BSON documents. Builders created with a
+// Schema reject field paths that do not exist in the struct. A Schema is safe for concurrent use by multiple
+// goroutines.
+//
+// Field names are resolved with a bsoncodec.StructTagParser, so they match the names used by the StructCodec when
+// the same parser is configured. Paths may traverse nested structs, maps, slices and arrays. Numeric path segments and
+// the positional operators $, $[] and $[<identifier>] are accepted for slices and arrays, and a field name following a
+// slice or array is checked against the element type. Paths into map, interface and document types (e.g. bson.D,
+// bson.M and bson.Raw), into structs with an inlined map and into types that implement bson.Marshaler or
+// bson.ValueMarshaler are not checked beyond that point.
+type Schema struct {
+	typ    reflect.Type
+	parser bsoncodec.StructTagParser
+
+	mu     sync.Mutex
+	fields map[reflect.Type]structFields
+}
+
+// structFields holds the BSON field names of a struct type mapped to their Go types. If open is true, the struct has
+// an inlined map and accepts any field name.
+type structFields struct {
+	types map[string]reflect.Type
+	open  bool
+}
+
+// NewSchema creates a Schema for the struct type of val, which must be a struct or a pointer to a struct. If parser is
+// nil, bsoncodec.DefaultStructTagParser is used.
+func NewSchema(val interface{}, parser bsoncodec.StructTagParser) (*Schema, error) {
+	if val == nil {
+		return nil, errors.New("schema value must not be nil")
+	}
+	return newSchema(reflect.TypeOf(val), parser)
+}
+
+// SchemaFor creates a Schema for the struct type T. If parser is nil, bsoncodec.DefaultStructTagParser is used.
+func SchemaFor[T any](parser bsoncodec.StructTagParser) (*Schema, error) {
+	return newSchema(reflect.TypeOf((*T)(nil)).Elem(), parser)
+}
+
+func newSchema(t reflect.Type, parser bsoncodec.StructTagParser) (*Schema, error) {
+	t = indirect(t)
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("schema type must be a struct, but was %s", t)
+	}
+	if parser == nil {
+		parser = bsoncodec.DefaultStructTagParser
+	}
+
+	s := &Schema{
+		typ:    t,
+		parser: parser,
+		fields: make(map[reflect.Type]structFields),
+	}
+	if _, err := s.structFields(t); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Type returns the struct type described by the Schema.
+func (s *Schema) Type() reflect.Type {
+	return s.typ
+}
+
+// Validate returns an error if path is not a valid dotted field path for the struct type described by the Schema.
+func (s *Schema) Validate(path string) error {
+	if path == "" {
+		return errors.New("field path must not be empty")
+	}
+	if err := s.validate(s.typ, strings.Split(path, ".")); err != nil {
+		return fmt.Errorf("invalid field path %q: %w", path, err)
+	}
+	return nil
+}
+
+func (s *Schema) validate(t reflect.Type, segments []string) error {
+	if len(segments) == 0 {
+		return nil
+	}
+	seg := segments[0]
+	if seg == "" {
+		return errors.New("empty path segment")
+	}
+
+	t = indirect(t)
+	if isOpaque(t) {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		fields, err := s.structFields(t)
+		if err != nil {
+			return err
+		}
+		if fields.open {
+			return nil
+		}
+		ft, ok := fields.types[seg]
+		if !ok {
+			return fmt.Errorf("%s has no field %q", t, seg)
+		}
+		return s.validate(ft, segments[1:])
+	case reflect.Map:
+		return s.validate(t.Elem(), segments[1:])
+	case reflect.Slice, reflect.Array:
+		if isArrayIndex(seg) {
+			return s.validate(t.Elem(), segments[1:])
+		}
+		return s.validate(t.Elem(), segments)
+	default:
+		return fmt.Errorf("%s has no field %q", t, seg)
+	}
+}
+
+// structFields returns the fields of the struct type t, including the fields of inlined structs.
+func (s *Schema) structFields(t reflect.Type) (structFields, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lockedStructFields(t)
+}
+
+func (s *Schema) lockedStructFields(t reflect.Type) (structFields, error) {
+	if fields, ok := s.fields[t]; ok {
+		return fields, nil
+	}
+
+	fields := structFields{types: make(map[string]reflect.Type)}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		tags, err := s.parser.ParseStructTags(sf)
+		if err != nil {
+			return structFields{}, fmt.Errorf("error parsing struct tags of %s.%s: %w", t, sf.Name, err)
+		}
+		if tags.Skip {
+			continue
+		}
+		if tags.Inline {
+			it := indirect(sf.Type)
+			if it.Kind() != reflect.Struct {
+				fields.open = true
+				continue
+			}
+			inlined, err := s.lockedStructFields(it)
+			if err != nil {
+				return structFields{}, err
+			}
+			fields.open = fields.open || inlined.open
+			for name, ft := range inlined.types {
+				fields.types[name] = ft
+			}
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		fields.types[tags.Name] = sf.Type
+	}
+
+	s.fields[t] = fields
+	return fields, nil
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isOpaque reports whether the contents of values of type t cannot be described by a Schema.
+func isOpaque(t reflect.Type) bool {
+	if t.Kind() == reflect.Interface {
+		return true
+	}
+	if t == tD || t == tRaw || t == tDocument {
+		return true
+	}
+	pt := reflect.PtrTo(t)
+	return t.Implements(tMarshaler) || t.Implements(tValueMarshaler) ||
+		pt.Implements(tMarshaler) || pt.Implements(tValueMarshaler)
+}
+
+// isArrayIndex reports whether seg is a numeric index or a positional update operator.
+func isArrayIndex(seg string) bool {
+	if seg == "$" || seg == "$[]" {
+		return true
+	}
+	if strings.HasPrefix(seg, "$[") && strings.HasSuffix(seg, "]") {
+		return true
+	}
+	_, err := strconv.Atoi(seg)
+	return err == nil
+}
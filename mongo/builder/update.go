@@ -0,0 +1,353 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package builder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+)
+
+// PushModifiers specifies the modifiers of a $push operation with $each.
+type PushModifiers struct {
+	// The index in the array at which to insert the values. Negative values count from the end of the array.
+	Position *int32
+
+	// The number of elements to keep after the push. Positive values keep the first elements, negative values keep
+	// the last elements and zero empties the array.
+	Slice *int32
+
+	// The order of the array after the push. This is either 1 or -1 to sort the elements by value, or a document
+	// specifying the sort order of fields of document elements.
+	Sort interface{}
+}
+
+// Push creates a new PushModifiers instance.
+func Push() *PushModifiers {
+	return &PushModifiers{}
+}
+
+// SetPosition sets the value for the Position field.
+func (pm *PushModifiers) SetPosition(position int32) *PushModifiers {
+	pm.Position = &position
+	return pm
+}
+
+// SetSlice sets the value for the Slice field.
+func (pm *PushModifiers) SetSlice(slice int32) *PushModifiers {
+	pm.Slice = &slice
+	return pm
+}
+
+// SetSort sets the value for the Sort field.
+func (pm *PushModifiers) SetSort(sort interface{}) *PushModifiers {
+	pm.Sort = sort
+	return pm
+}
+
+// UpdateBuilder builds an update document. Updates to different fields with the same operator are combined into a
+// single operator document, e.g. Set("a", 1).Set("b", 2) builds {$set: {a: 1, b: 2}}.
+//
+// Field paths may use the positional operators $, $[] and $[<identifier>]. Every identifier used in a field path must
+// have a matching array filter added with ArrayFilter, and every array filter must be used.
+//
+// If the builder was created with a Schema, every field path is validated against it. The first error encountered is
+// returned by Build and all later calls are no-ops.
+type UpdateBuilder struct {
+	schema       *Schema
+	doc          bson.D
+	arrayFilters []interface{}
+	filterIDs    map[string]bool
+	usedIDs      map[string]bool
+	err          error
+}
+
+// Update creates a new UpdateBuilder that does not validate field paths.
+func Update() *UpdateBuilder {
+	return &UpdateBuilder{}
+}
+
+// UpdateFor creates a new UpdateBuilder that validates field paths against schema.
+func UpdateFor(schema *Schema) *UpdateBuilder {
+	return &UpdateBuilder{schema: schema}
+}
+
+// Build returns the update document or the first error encountered while building it. An update document must
+// contain at least one update operator.
+func (ub *UpdateBuilder) Build() (bson.D, error) {
+	if ub.err != nil {
+		return nil, ub.err
+	}
+	if len(ub.doc) == 0 {
+		return nil, fmt.Errorf("update document must contain at least one update operator")
+	}
+	for _, id := range sortedKeys(ub.usedIDs) {
+		if !ub.filterIDs[id] {
+			return nil, fmt.Errorf("no array filter found for identifier %q", id)
+		}
+	}
+	for _, id := range sortedKeys(ub.filterIDs) {
+		if !ub.usedIDs[id] {
+			return nil, fmt.Errorf("the array filter for identifier %q was not used in the update", id)
+		}
+	}
+	return ub.doc, nil
+}
+
+// ArrayFilters returns the array filters added with ArrayFilter, for use with the SetArrayFilters method of
+// options.UpdateOptions or options.FindOneAndUpdateOptions.
+func (ub *UpdateBuilder) ArrayFilters() options.ArrayFilters {
+	return options.ArrayFilters{Filters: ub.arrayFilters}
+}
+
+// Set adds a $set of field to value.
+func (ub *UpdateBuilder) Set(field string, value interface{}) *UpdateBuilder {
+	return ub.op("$set", field, value)
+}
+
+// SetOnInsert adds a $setOnInsert of field to value, which is only applied if the update results in an insert.
+func (ub *UpdateBuilder) SetOnInsert(field string, value interface{}) *UpdateBuilder {
+	return ub.op("$setOnInsert", field, value)
+}
+
+// Unset adds an $unset that removes field.
+func (ub *UpdateBuilder) Unset(field string) *UpdateBuilder {
+	return ub.op("$unset", field, "")
+}
+
+// Inc adds an $inc of field by amount.
+func (ub *UpdateBuilder) Inc(field string, amount interface{}) *UpdateBuilder {
+	return ub.op("$inc", field, amount)
+}
+
+// Mul adds a $mul of field by factor.
+func (ub *UpdateBuilder) Mul(field string, factor interface{}) *UpdateBuilder {
+	return ub.op("$mul", field, factor)
+}
+
+// Min adds a $min that sets field to value if value is less than the current value.
+func (ub *UpdateBuilder) Min(field string, value interface{}) *UpdateBuilder {
+	return ub.op("$min", field, value)
+}
+
+// Max adds a $max that sets field to value if value is greater than the current value.
+func (ub *UpdateBuilder) Max(field string, value interface{}) *UpdateBuilder {
+	return ub.op("$max", field, value)
+}
+
+// Rename adds a $rename of field to newName. Both names are validated if the builder has a Schema.
+func (ub *UpdateBuilder) Rename(field, newName string) *UpdateBuilder {
+	if ub.err == nil {
+		if err := validateField(ub.schema, newName); err != nil {
+			return ub.fail(err)
+		}
+	}
+	return ub.op("$rename", field, newName)
+}
+
+// CurrentDate adds a $currentDate that sets field to the current date.
+func (ub *UpdateBuilder) CurrentDate(field string) *UpdateBuilder {
+	return ub.op("$currentDate", field, true)
+}
+
+// CurrentTimestamp adds a $currentDate that sets field to the current timestamp.
+func (ub *UpdateBuilder) CurrentTimestamp(field string) *UpdateBuilder {
+	return ub.op("$currentDate", field, bson.D{{"$type", "timestamp"}})
+}
+
+// Push adds a $push that appends value to the array field.
+func (ub *UpdateBuilder) Push(field string, value interface{}) *UpdateBuilder {
+	return ub.op("$push", field, value)
+}
+
+// PushEach adds a $push that appends each of values to the array field, applying the given modifiers. The mods
+// parameter may be nil.
+func (ub *UpdateBuilder) PushEach(field string, values []interface{}, mods *PushModifiers) *UpdateBuilder {
+	doc := bson.D{{"$each", bson.A(values)}}
+	if mods != nil {
+		if mods.Position != nil {
+			doc = append(doc, bson.E{"$position", *mods.Position})
+		}
+		if mods.Slice != nil {
+			doc = append(doc, bson.E{"$slice", *mods.Slice})
+		}
+		if mods.Sort != nil {
+			doc = append(doc, bson.E{"$sort", mods.Sort})
+		}
+	}
+	return ub.op("$push", field, doc)
+}
+
+// AddToSet adds an $addToSet that appends value to the array field unless it is already present.
+func (ub *UpdateBuilder) AddToSet(field string, value interface{}) *UpdateBuilder {
+	return ub.op("$addToSet", field, value)
+}
+
+// AddToSetEach adds an $addToSet that appends each of values to the array field unless it is already present.
+func (ub *UpdateBuilder) AddToSetEach(field string, values ...interface{}) *UpdateBuilder {
+	return ub.op("$addToSet", field, bson.D{{"$each", bson.A(values)}})
+}
+
+// Pull adds a $pull that removes all elements equal to value from the array field.
+func (ub *UpdateBuilder) Pull(field string, value interface{}) *UpdateBuilder {
+	return ub.op("$pull", field, value)
+}
+
+// PullIf adds a $pull that removes all elements matching cond from the array field. The cond parameter should be
+// created with ElementFilter or ElementFilterFor.
+func (ub *UpdateBuilder) PullIf(field string, cond *FilterBuilder) *UpdateBuilder {
+	doc, err := cond.Build()
+	if err != nil {
+		return ub.fail(err)
+	}
+
+	return ub.op("$pull", field, elementCondition(doc))
+}
+
+// PullAll adds a $pullAll that removes all elements equal to any of values from the array field.
+func (ub *UpdateBuilder) PullAll(field string, values ...interface{}) *UpdateBuilder {
+	return ub.op("$pullAll", field, bson.A(values))
+}
+
+// PopFirst adds a $pop that removes the first element of the array field.
+func (ub *UpdateBuilder) PopFirst(field string) *UpdateBuilder {
+	return ub.op("$pop", field, int32(-1))
+}
+
+// PopLast adds a $pop that removes the last element of the array field.
+func (ub *UpdateBuilder) PopLast(field string) *UpdateBuilder {
+	return ub.op("$pop", field, int32(1))
+}
+
+// ArrayFilter adds an array filter for the positional operator $[identifier]. The identifier must begin with a
+// lowercase letter and contain only alphanumeric characters. The cond parameter should be created with ElementFilter or
+// ElementFilterFor; its field paths are relative to the array element and are prefixed with the identifier, and the
+// empty path refers to the element itself.
+func (ub *UpdateBuilder) ArrayFilter(identifier string, cond *FilterBuilder) *UpdateBuilder {
+	if ub.err != nil {
+		return ub
+	}
+	if !isIdentifier(identifier) {
+		return ub.fail(fmt.Errorf("invalid array filter identifier %q", identifier))
+	}
+	if ub.filterIDs[identifier] {
+		return ub.fail(fmt.Errorf("duplicate array filter for identifier %q", identifier))
+	}
+
+	doc, err := cond.Build()
+	if err != nil {
+		return ub.fail(err)
+	}
+	filter, err := prefixFields(doc, identifier)
+	if err != nil {
+		return ub.fail(err)
+	}
+
+	if ub.filterIDs == nil {
+		ub.filterIDs = make(map[string]bool)
+	}
+	ub.filterIDs[identifier] = true
+	ub.arrayFilters = append(ub.arrayFilters, filter)
+	return ub
+}
+
+// op adds {operator: {field: value}}, merging it into an existing document for operator if there is one.
+func (ub *UpdateBuilder) op(operator, field string, value interface{}) *UpdateBuilder {
+	if ub.err != nil {
+		return ub
+	}
+	if err := validateField(ub.schema, field); err != nil {
+		return ub.fail(err)
+	}
+	ub.recordIdentifiers(field)
+
+	for i, elem := range ub.doc {
+		if elem.Key != operator {
+			continue
+		}
+		ub.doc[i].Value = append(elem.Value.(bson.D), bson.E{field, value})
+		return ub
+	}
+	ub.doc = append(ub.doc, bson.E{operator, bson.D{{field, value}}})
+	return ub
+}
+
+// recordIdentifiers records the identifiers of the $[<identifier>] positional operators in field.
+func (ub *UpdateBuilder) recordIdentifiers(field string) {
+	for _, seg := range strings.Split(field, ".") {
+		if len(seg) <= 3 || !strings.HasPrefix(seg, "$[") || !strings.HasSuffix(seg, "]") {
+			continue
+		}
+		if ub.usedIDs == nil {
+			ub.usedIDs = make(map[string]bool)
+		}
+		ub.usedIDs[seg[2:len(seg)-1]] = true
+	}
+}
+
+func (ub *UpdateBuilder) fail(err error) *UpdateBuilder {
+	if ub.err == nil {
+		ub.err = err
+	}
+	return ub
+}
+
+// prefixFields returns a copy of the filter doc with every field path prefixed with identifier. Field paths inside
+// $and, $or and $nor are prefixed recursively.
+func prefixFields(doc bson.D, identifier string) (bson.D, error) {
+	out := make(bson.D, 0, len(doc))
+	for _, elem := range doc {
+		switch {
+		case elem.Key == "":
+			out = append(out, bson.E{identifier, elem.Value})
+		case elem.Key == "$and" || elem.Key == "$or" || elem.Key == "$nor":
+			arr, _ := elem.Value.(bson.A)
+			prefixed := make(bson.A, 0, len(arr))
+			for _, v := range arr {
+				sub, ok := v.(bson.D)
+				if !ok {
+					return nil, fmt.Errorf("unexpected %s operand of type %T in array filter", elem.Key, v)
+				}
+				p, err := prefixFields(sub, identifier)
+				if err != nil {
+					return nil, err
+				}
+				prefixed = append(prefixed, p)
+			}
+			out = append(out, bson.E{elem.Key, prefixed})
+		case elem.Key[0] == '$':
+			return nil, fmt.Errorf("%s is not supported in array filters", elem.Key)
+		default:
+			out = append(out, bson.E{identifier + "." + elem.Key, elem.Value})
+		}
+	}
+	return out, nil
+}
+
+func isIdentifier(s string) bool {
+	if s == "" || s[0] < 'a' || s[0] > 'z' {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
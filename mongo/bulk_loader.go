@@ -0,0 +1,570 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/bsontype"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/description"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver/topology"
+)
+
+// ErrBulkLoaderClosed is returned by BulkLoader methods called after Close.
+var ErrBulkLoaderClosed = errors.New("bulk loader is closed")
+
+const (
+	defaultBulkLoaderBatchSize = 1000
+
+	// bulkLoaderCommandOverhead is the space of a message reserved for the fields of the command other than the
+	// write models.
+	bulkLoaderCommandOverhead = 16 * 1024
+
+	// Server limits used if the server description does not report them.
+	defaultMaxDocumentSize = 16 * 1024 * 1024
+	defaultMaxMessageSize  = 48000000
+)
+
+// BulkLoader writes a stream of write models to a collection in batches. Write models are added one at a time with
+// Add, Insert or AddFrom and are written with BulkWrite once a batch is full, when the flush interval elapses, or when
+// Flush or Close is called. Several batches can be written concurrently. It is safe for concurrent use by multiple
+// goroutines.
+//
+// Write errors do not stop the loader. They are collected, together with the counts of the writes, and returned by
+// Flush and Close in a BulkWriteException whose WriteError indexes are the positions of the write models in the order
+// in which they were added. Any other error, such as a network error, stops the loader and is returned by all later
+// calls.
+type BulkLoader struct {
+	coll          *Collection
+	ctx           context.Context
+	batchSize     int
+	maxBatchBytes int64
+	bwOpts        *options.BulkWriteOptions
+	sem           chan struct{}
+	stop          chan struct{}
+	stopped       chan struct{}
+
+	mu              sync.Mutex
+	limitsKnown     bool
+	maxDocumentSize int
+	pending         []bulkLoaderItem
+	pendingStart    int64
+	pendingBytes    int64
+	inflight        int
+	idle            chan struct{}
+	result          BulkWriteResult
+	exception       *BulkWriteException
+	err             error
+	closed          bool
+}
+
+// bulkLoaderItem is a write model waiting to be written by a BulkLoader.
+type bulkLoaderItem struct {
+	// model is the write model that is written, with its documents already marshalled.
+	model WriteModel
+	// original is the write model that was added.
+	original WriteModel
+	size     int
+}
+
+// bulkLoaderBatch is a batch of write models. The index of the first write model in the input of the loader is start.
+type bulkLoaderBatch struct {
+	items []bulkLoaderItem
+	start int64
+}
+
+// BulkLoader creates a BulkLoader that writes to the collection. The ctx parameter is used for the writes of all
+// batches, and cancelling it stops the loader. The loader must be closed with Close to write the remaining write models
+// and release its resources.
+//
+// The opts parameter can be used to specify options for the loader (see the options.BulkLoaderOptions documentation).
+func (coll *Collection) BulkLoader(ctx context.Context, opts ...*options.BulkLoaderOptions) (*BulkLoader, error) {
+	blo := options.MergeBulkLoaderOptions(opts...)
+
+	bl := &BulkLoader{
+		coll:          coll,
+		ctx:           ctx,
+		batchSize:     defaultBulkLoaderBatchSize,
+		maxBatchBytes: -1,
+		bwOpts:        options.MergeBulkWriteOptions(options.BulkWrite().SetOrdered(false), blo.BulkWriteOptions),
+		idle:          make(chan struct{}),
+	}
+	close(bl.idle)
+
+	if blo.BatchSize != nil {
+		if *blo.BatchSize <= 0 {
+			return nil, fmt.Errorf("batch size must be positive, but was %d", *blo.BatchSize)
+		}
+		bl.batchSize = *blo.BatchSize
+	}
+	if blo.MaxBatchBytes != nil {
+		if *blo.MaxBatchBytes <= 0 {
+			return nil, fmt.Errorf("maximum batch size in bytes must be positive, but was %d", *blo.MaxBatchBytes)
+		}
+		bl.maxBatchBytes = *blo.MaxBatchBytes
+	}
+	concurrency := 1
+	if blo.Concurrency != nil {
+		if *blo.Concurrency <= 0 {
+			return nil, fmt.Errorf("concurrency must be positive, but was %d", *blo.Concurrency)
+		}
+		concurrency = *blo.Concurrency
+	}
+	bl.sem = make(chan struct{}, concurrency)
+
+	if blo.FlushInterval != nil && *blo.FlushInterval > 0 {
+		bl.stop = make(chan struct{})
+		bl.stopped = make(chan struct{})
+		go bl.flushPeriodically(*blo.FlushInterval)
+	}
+	return bl, nil
+}
+
+// Insert adds an insert of document to the loader. It is equivalent to calling Add with an InsertOneModel.
+func (bl *BulkLoader) Insert(ctx context.Context, document interface{}) error {
+	return bl.Add(ctx, NewInsertOneModel().SetDocument(document))
+}
+
+// Add adds a write model to the loader. The documents of the model are marshalled immediately, so the model can be
+// reused once Add returns. If the model completes a batch, Add blocks while the maximum number of batches are already
+// being written and then starts writing the batch. If ctx is done first, Add returns the context error and the model
+// is not added, so it can be safely added again.
+func (bl *BulkLoader) Add(ctx context.Context, model WriteModel) error {
+	if model == nil {
+		return ErrNilDocument
+	}
+	if err := bl.ensureLimits(ctx); err != nil {
+		return err
+	}
+	prepared, size, err := bl.prepare(model)
+	if err != nil {
+		return err
+	}
+
+	bl.mu.Lock()
+	if err := bl.checkOpen(); err != nil {
+		bl.mu.Unlock()
+		return err
+	}
+	if bl.maxDocumentSize > 0 && size > bl.maxDocumentSize {
+		bl.mu.Unlock()
+		return fmt.Errorf("write model of %d bytes is larger than the maximum document size of %d bytes", size,
+			bl.maxDocumentSize)
+	}
+	full := len(bl.pending)+1 >= bl.batchSize || bl.pendingBytes+int64(size) >= bl.maxBatchBytes
+	bl.mu.Unlock()
+
+	// Wait for a write slot before accepting a model that completes a batch, so that the model is not left pending if
+	// ctx is done while waiting.
+	slot := false
+	if full {
+		if err := bl.acquireSlot(ctx); err != nil {
+			return err
+		}
+		slot = true
+	}
+
+	bl.mu.Lock()
+	if err := bl.checkOpen(); err != nil {
+		bl.mu.Unlock()
+		if slot {
+			<-bl.sem
+		}
+		return err
+	}
+	bl.pending = append(bl.pending, bulkLoaderItem{model: prepared, original: model, size: size})
+	bl.pendingBytes += int64(size)
+	full = len(bl.pending) >= bl.batchSize || bl.pendingBytes >= bl.maxBatchBytes
+	bl.mu.Unlock()
+
+	// The pending models may have changed while waiting for the slot. If the batch is full but no slot is free, it is
+	// written by the next call to Add or Flush.
+	switch {
+	case full && slot:
+		bl.startBatch()
+	case slot:
+		<-bl.sem
+	case full:
+		select {
+		case bl.sem <- struct{}{}:
+			bl.startBatch()
+		default:
+		}
+	}
+	return nil
+}
+
+// AddFrom adds the write models received from models until the channel is closed, an error occurs or ctx is done.
+func (bl *BulkLoader) AddFrom(ctx context.Context, models <-chan WriteModel) error {
+	for {
+		select {
+		case model, ok := <-models:
+			if !ok {
+				return nil
+			}
+			if err := bl.Add(ctx, model); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Flush writes all of the write models added so far and waits for the writes to finish. It returns the first error
+// that stopped the loader or a BulkWriteException with all of the write errors that have occurred so far.
+func (bl *BulkLoader) Flush(ctx context.Context) error {
+	for {
+		bl.mu.Lock()
+		empty := len(bl.pending) == 0
+		bl.mu.Unlock()
+		if empty {
+			break
+		}
+		if err := bl.dispatch(ctx); err != nil {
+			return err
+		}
+	}
+
+	bl.mu.Lock()
+	idle := bl.idle
+	bl.mu.Unlock()
+	select {
+	case <-idle:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	return bl.errorLocked()
+}
+
+// Close writes the remaining write models, waits for the writes to finish and closes the loader. It returns the
+// combined result of all of the writes and the same error as Flush.
+func (bl *BulkLoader) Close(ctx context.Context) (*BulkWriteResult, error) {
+	bl.mu.Lock()
+	if bl.closed {
+		bl.mu.Unlock()
+		return nil, ErrBulkLoaderClosed
+	}
+	bl.closed = true
+	bl.mu.Unlock()
+
+	if bl.stop != nil {
+		close(bl.stop)
+		<-bl.stopped
+	}
+	err := bl.Flush(ctx)
+
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	res := bl.result
+	return &res, err
+}
+
+// checkOpen returns an error if the loader is closed or stopped. It must be called with mu held.
+func (bl *BulkLoader) checkOpen() error {
+	if bl.closed {
+		return ErrBulkLoaderClosed
+	}
+	return bl.err
+}
+
+// ensureLimits reads the maximum document and message sizes from a server description the first time it is called.
+func (bl *BulkLoader) ensureLimits(ctx context.Context) error {
+	bl.mu.Lock()
+	known := bl.limitsKnown
+	bl.mu.Unlock()
+	if known {
+		return nil
+	}
+
+	server, err := bl.coll.client.deployment.SelectServer(ctx, bl.coll.writeSelector)
+	if err != nil {
+		return err
+	}
+	// The limits are read from the server description of the last heartbeat, so no connection is checked out. The
+	// defaults are used for deployments that do not expose server descriptions.
+	var desc description.Server
+	if ss, ok := server.(*topology.SelectedServer); ok {
+		desc = ss.Description().Server
+	}
+
+	maxDocumentSize, maxMessageSize := int(desc.MaxDocumentSize), int64(desc.MaxMessageSize)
+	if maxDocumentSize == 0 {
+		maxDocumentSize = defaultMaxDocumentSize
+	}
+	if maxMessageSize == 0 {
+		maxMessageSize = defaultMaxMessageSize
+	}
+
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	bl.limitsKnown = true
+	bl.maxDocumentSize = maxDocumentSize
+	if limit := maxMessageSize - bulkLoaderCommandOverhead; bl.maxBatchBytes < 0 || bl.maxBatchBytes > limit {
+		bl.maxBatchBytes = limit
+	}
+	return nil
+}
+
+// prepare returns a copy of model with its documents marshalled and the size of the documents in bytes.
+func (bl *BulkLoader) prepare(model WriteModel) (WriteModel, int, error) {
+	bsonOpts, registry := bl.coll.bsonOpts, bl.coll.registry
+	switch m := model.(type) {
+	case *InsertOneModel:
+		doc, err := marshal(m.Document, bsonOpts, registry)
+		if err != nil {
+			return nil, 0, err
+		}
+		return &InsertOneModel{Document: bson.Raw(doc)}, len(doc), nil
+	case *ReplaceOneModel:
+		filter, err := marshal(m.Filter, bsonOpts, registry)
+		if err != nil {
+			return nil, 0, err
+		}
+		replacement, err := marshal(m.Replacement, bsonOpts, registry)
+		if err != nil {
+			return nil, 0, err
+		}
+		c := *m
+		c.Filter, c.Replacement = bson.Raw(filter), bson.Raw(replacement)
+		return &c, len(filter) + len(replacement), nil
+	case *UpdateOneModel:
+		filter, update, size, err := bl.marshalUpdate(m.Filter, m.Update)
+		if err != nil {
+			return nil, 0, err
+		}
+		c := *m
+		c.Filter, c.Update = bson.Raw(filter), update
+		return &c, len(filter) + size, nil
+	case *UpdateManyModel:
+		filter, update, size, err := bl.marshalUpdate(m.Filter, m.Update)
+		if err != nil {
+			return nil, 0, err
+		}
+		c := *m
+		c.Filter, c.Update = bson.Raw(filter), update
+		return &c, len(filter) + size, nil
+	case *DeleteOneModel:
+		filter, err := marshal(m.Filter, bsonOpts, registry)
+		if err != nil {
+			return nil, 0, err
+		}
+		c := *m
+		c.Filter = bson.Raw(filter)
+		return &c, len(filter), nil
+	case *DeleteManyModel:
+		filter, err := marshal(m.Filter, bsonOpts, registry)
+		if err != nil {
+			return nil, 0, err
+		}
+		c := *m
+		c.Filter = bson.Raw(filter)
+		return &c, len(filter), nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported write model type %T", model)
+	}
+}
+
+// marshalUpdate marshals the filter and update of an update write model. The update is returned as a bson.Raw
+// document or a []bson.Raw pipeline together with its size.
+func (bl *BulkLoader) marshalUpdate(filter, update interface{}) ([]byte, interface{}, int, error) {
+	f, err := marshal(filter, bl.coll.bsonOpts, bl.coll.registry)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	u, err := marshalUpdateValue(update, bl.coll.bsonOpts, bl.coll.registry, true)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if u.Type != bsontype.Array {
+		return f, bson.Raw(u.Data), len(u.Data), nil
+	}
+
+	values, err := bsoncore.Array(u.Data).Values()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	pipeline := make([]bson.Raw, 0, len(values))
+	for _, v := range values {
+		pipeline = append(pipeline, bson.Raw(v.Data))
+	}
+	return f, pipeline, len(u.Data), nil
+}
+
+// dispatch waits for a free write slot and starts writing the next batch of pending write models.
+func (bl *BulkLoader) dispatch(ctx context.Context) error {
+	if err := bl.acquireSlot(ctx); err != nil {
+		return err
+	}
+	bl.startBatch()
+	return nil
+}
+
+// acquireSlot waits for a free write slot.
+func (bl *BulkLoader) acquireSlot(ctx context.Context) error {
+	select {
+	case bl.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-bl.ctx.Done():
+		return bl.ctx.Err()
+	}
+}
+
+// startBatch starts writing the next batch of pending write models in the write slot held by the caller. The slot is
+// released once the batch has been written, or immediately if there are no pending write models.
+func (bl *BulkLoader) startBatch() {
+	bl.mu.Lock()
+	batch := bl.takeBatch()
+	if len(batch.items) == 0 {
+		bl.mu.Unlock()
+		<-bl.sem
+		return
+	}
+	if bl.inflight == 0 {
+		bl.idle = make(chan struct{})
+	}
+	bl.inflight++
+	bl.mu.Unlock()
+
+	go bl.write(batch)
+}
+
+// takeBatch removes the next batch from the pending write models. It must be called with mu held.
+func (bl *BulkLoader) takeBatch() bulkLoaderBatch {
+	var n int
+	var size int64
+	for n < len(bl.pending) && n < bl.batchSize {
+		if n > 0 && size+int64(bl.pending[n].size) > bl.maxBatchBytes {
+			break
+		}
+		size += int64(bl.pending[n].size)
+		n++
+	}
+
+	batch := bulkLoaderBatch{items: bl.pending[:n:n], start: bl.pendingStart}
+	bl.pending = bl.pending[n:]
+	bl.pendingStart += int64(n)
+	bl.pendingBytes -= size
+	return batch
+}
+
+// write writes a batch and records its result.
+func (bl *BulkLoader) write(batch bulkLoaderBatch) {
+	models := make([]WriteModel, len(batch.items))
+	for i, item := range batch.items {
+		models[i] = item.model
+	}
+	res, err := bl.coll.BulkWrite(bl.ctx, models, bl.bwOpts)
+	<-bl.sem
+
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	bl.merge(batch, res, err)
+	bl.inflight--
+	if bl.inflight == 0 {
+		close(bl.idle)
+	}
+}
+
+// merge adds the result and error of writing batch to the result and errors of the loader. It must be called with mu
+// held.
+func (bl *BulkLoader) merge(batch bulkLoaderBatch, res *BulkWriteResult, err error) {
+	if res != nil {
+		bl.result.InsertedCount += res.InsertedCount
+		bl.result.MatchedCount += res.MatchedCount
+		bl.result.ModifiedCount += res.ModifiedCount
+		bl.result.DeletedCount += res.DeletedCount
+		bl.result.UpsertedCount += res.UpsertedCount
+		for i, id := range res.UpsertedIDs {
+			if bl.result.UpsertedIDs == nil {
+				bl.result.UpsertedIDs = make(map[int64]interface{})
+			}
+			bl.result.UpsertedIDs[batch.start+i] = id
+		}
+	}
+
+	var bwe BulkWriteException
+	if !errors.As(err, &bwe) {
+		if err != nil && bl.err == nil {
+			bl.err = err
+		}
+		return
+	}
+
+	if bl.exception == nil {
+		bl.exception = &BulkWriteException{}
+	}
+	for _, we := range bwe.WriteErrors {
+		if we.Index >= 0 && we.Index < len(batch.items) {
+			we.Request = batch.items[we.Index].original
+		}
+		we.Index += int(batch.start)
+		bl.exception.WriteErrors = append(bl.exception.WriteErrors, we)
+	}
+	if bl.exception.WriteConcernError == nil {
+		bl.exception.WriteConcernError = bwe.WriteConcernError
+	}
+	for _, label := range bwe.Labels {
+		if !bl.exception.HasErrorLabel(label) {
+			bl.exception.Labels = append(bl.exception.Labels, label)
+		}
+	}
+}
+
+// errorLocked returns the error that stopped the loader or a copy of the collected write errors sorted by index. It
+// must be called with mu held.
+func (bl *BulkLoader) errorLocked() error {
+	if bl.err != nil {
+		return bl.err
+	}
+	if bl.exception == nil {
+		return nil
+	}
+
+	exc := *bl.exception
+	exc.WriteErrors = append([]BulkWriteError(nil), bl.exception.WriteErrors...)
+	sort.Slice(exc.WriteErrors, func(i, j int) bool {
+		return exc.WriteErrors[i].Index < exc.WriteErrors[j].Index
+	})
+	return exc
+}
+
+// flushPeriodically starts writing the pending write models every interval until the loader is closed.
+func (bl *BulkLoader) flushPeriodically(interval time.Duration) {
+	defer close(bl.stopped)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			bl.mu.Lock()
+			empty := len(bl.pending) == 0
+			bl.mu.Unlock()
+			if !empty {
+				_ = bl.dispatch(bl.ctx)
+			}
+		case <-bl.stop:
+			return
+		case <-bl.ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/bsontype"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/primitive"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+)
+
+// ErrInvalidCausalToken is returned by ParseCausalToken if a causal token is malformed.
+var ErrInvalidCausalToken = errors.New("mongo: invalid causal token")
+
+// causalTokenVersion is the version of the causal token format.
+const causalTokenVersion = 1
+
+// CausalToken holds the cluster time and operation time observed by a session or client. It can be passed to another
+// process, for example in an HTTP header, to start a causally consistent session that observes every write that had
+// been acknowledged when the token was taken.
+//
+// The token is not encrypted. The cluster time is signed by the server, so it cannot be forged, but it can be read by
+// anyone who has the token.
+type CausalToken struct {
+	// ClusterTime is the cluster time document, in the form {"$clusterTime": {...}}. It is nil if no cluster time
+	// was known.
+	ClusterTime bson.Raw
+
+	// OperationTime is the time of the last operation. It is nil if no operation time was known.
+	OperationTime *primitive.Timestamp
+}
+
+// CausalToken returns a token that holds the latest cluster time seen by the Client. Because the Client does not track
+// operation times, the timestamp of the cluster time is used as the operation time. A session started from the token
+// observes every write acknowledged by the Client, but may wait longer for a secondary to catch up than a session
+// started from the token of the session that made the writes.
+func (c *Client) CausalToken() *CausalToken {
+	token := &CausalToken{ClusterTime: c.clock.GetClusterTime()}
+	if val, err := token.ClusterTime.LookupErr("$clusterTime", "clusterTime"); err == nil {
+		if t, i, ok := val.TimestampOK(); ok {
+			token.OperationTime = &primitive.Timestamp{T: t, I: i}
+		}
+	}
+	return token
+}
+
+// CausalToken implements the Session interface.
+func (s *sessionImpl) CausalToken() *CausalToken {
+	token := &CausalToken{ClusterTime: s.clientSession.ClusterTime}
+	if ts := s.clientSession.OperationTime; ts != nil {
+		token.OperationTime = &primitive.Timestamp{T: ts.T, I: ts.I}
+	}
+	return token
+}
+
+// StartCausalSession starts a causally consistent session whose cluster time and operation time are advanced to those
+// of token, so that reads in the session observe the writes made before the token was taken. A nil token starts a
+// causally consistent session without advancing it. The CausalConsistency field of opts is ignored.
+func (c *Client) StartCausalSession(token *CausalToken, opts ...*options.SessionOptions) (Session, error) {
+	sopts := options.MergeSessionOptions(opts...).SetCausalConsistency(true)
+	sess, err := c.StartSession(sopts)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return sess, nil
+	}
+
+	if token.ClusterTime != nil {
+		if err := sess.AdvanceClusterTime(token.ClusterTime); err != nil {
+			sess.EndSession(context.Background())
+			return nil, err
+		}
+	}
+	if token.OperationTime != nil {
+		if err := sess.AdvanceOperationTime(token.OperationTime); err != nil {
+			sess.EndSession(context.Background())
+			return nil, err
+		}
+	}
+	return sess, nil
+}
+
+// String returns the token encoded as an unpadded base64url string, which can be parsed with ParseCausalToken.
+func (t *CausalToken) String() string {
+	b := bsoncore.NewDocumentBuilder().AppendInt32("v", causalTokenVersion)
+	if t.ClusterTime != nil {
+		b.AppendDocument("c", t.ClusterTime)
+	}
+	if t.OperationTime != nil {
+		b.AppendTimestamp("o", t.OperationTime.T, t.OperationTime.I)
+	}
+	return base64.RawURLEncoding.EncodeToString(b.Build())
+}
+
+// ParseCausalToken parses a token returned by CausalToken.String. It returns ErrInvalidCausalToken if s is not a valid
+// token.
+func ParseCausalToken(s string) (*CausalToken, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCausalToken
+	}
+	doc := bsoncore.Document(raw)
+	if doc.Validate() != nil {
+		return nil, ErrInvalidCausalToken
+	}
+	if version, ok := doc.Lookup("v").Int32OK(); !ok || version != causalTokenVersion {
+		return nil, ErrInvalidCausalToken
+	}
+
+	token := &CausalToken{}
+	if val, err := doc.LookupErr("c"); err == nil {
+		if val.Type != bsontype.EmbeddedDocument {
+			return nil, ErrInvalidCausalToken
+		}
+		ct := bson.Raw(val.Document())
+		if _, err := ct.LookupErr("$clusterTime", "clusterTime"); err != nil {
+			return nil, ErrInvalidCausalToken
+		}
+		token.ClusterTime = ct
+	}
+	if val, err := doc.LookupErr("o"); err == nil {
+		t, i, ok := val.TimestampOK()
+		if !ok {
+			return nil, ErrInvalidCausalToken
+		}
+		token.OperationTime = &primitive.Timestamp{T: t, I: i}
+	}
+	return token, nil
+}
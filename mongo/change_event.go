@@ -0,0 +1,132 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"time"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/primitive"
+)
+
+// OperationType is the type of operation that caused a change event.
+type OperationType string
+
+// These constants are the operation types of change events. The DDL operation types other than drop, dropDatabase,
+// rename and invalidate are only reported if the ShowExpandedEvents option is set.
+const (
+	OperationTypeInsert                   OperationType = "insert"
+	OperationTypeUpdate                   OperationType = "update"
+	OperationTypeReplace                  OperationType = "replace"
+	OperationTypeDelete                   OperationType = "delete"
+	OperationTypeDrop                     OperationType = "drop"
+	OperationTypeRename                   OperationType = "rename"
+	OperationTypeDropDatabase             OperationType = "dropDatabase"
+	OperationTypeInvalidate               OperationType = "invalidate"
+	OperationTypeCreate                   OperationType = "create"
+	OperationTypeCreateIndexes            OperationType = "createIndexes"
+	OperationTypeDropIndexes              OperationType = "dropIndexes"
+	OperationTypeModify                   OperationType = "modify"
+	OperationTypeShardCollection          OperationType = "shardCollection"
+	OperationTypeReshardCollection        OperationType = "reshardCollection"
+	OperationTypeRefineCollectionShardKey OperationType = "refineCollectionShardKey"
+)
+
+// ChangeEvent is a change stream event whose full document and pre-image are decoded into a T. Fields that do not
+// apply to the operation type of the event are left as their zero value.
+//
+// A change stream can be decoded into a ChangeEvent with ChangeStream.Decode or iterated with
+// AllEvents[ChangeEvent[T]].
+type ChangeEvent[T any] struct {
+	// ID is the resume token of the event.
+	ID bson.Raw `bson:"_id"`
+
+	// OperationType is the type of operation that caused the event.
+	OperationType OperationType `bson:"operationType"`
+
+	// FullDocument is the document created by an insert or replace, or the current version of the document for an
+	// update if the FullDocument option was set. It is nil for other events, and for an update of a document that has
+	// since been deleted.
+	FullDocument *T `bson:"fullDocument,omitempty"`
+
+	// FullDocumentBeforeChange is the pre-image of the document for update, replace and delete events if the
+	// FullDocumentBeforeChange option was set.
+	FullDocumentBeforeChange *T `bson:"fullDocumentBeforeChange,omitempty"`
+
+	// DocumentKey holds the _id and, for sharded collections, the shard key of the document for insert, update,
+	// replace and delete events.
+	DocumentKey bson.Raw `bson:"documentKey,omitempty"`
+
+	// UpdateDescription describes the fields changed by an update event.
+	UpdateDescription *UpdateDescription `bson:"updateDescription,omitempty"`
+
+	// Namespace is the namespace affected by the event. Collection is empty for dropDatabase events.
+	Namespace ChangeNamespace `bson:"ns"`
+
+	// To is the new namespace of a rename event.
+	To *ChangeNamespace `bson:"to,omitempty"`
+
+	// ClusterTime is the time of the oplog entry of the event.
+	ClusterTime primitive.Timestamp `bson:"clusterTime"`
+
+	// WallTime is the server date and time of the event. It is only reported by MongoDB 6.0 and later.
+	WallTime time.Time `bson:"wallTime,omitempty"`
+
+	// CollectionUUID is the UUID of the collection affected by the event if the ShowExpandedEvents option was set.
+	CollectionUUID *primitive.Binary `bson:"collectionUUID,omitempty"`
+
+	// OperationDescription describes the DDL operation of an expanded event, such as the indexes created by a
+	// createIndexes event or the new shard key of a refineCollectionShardKey event. Its fields depend on the operation
+	// type.
+	OperationDescription bson.Raw `bson:"operationDescription,omitempty"`
+
+	// TxnNumber is the transaction number if the event is part of a multi-document transaction.
+	TxnNumber *int64 `bson:"txnNumber,omitempty"`
+
+	// LSID is the ID of the session that ran the transaction if the event is part of a multi-document transaction.
+	LSID bson.Raw `bson:"lsid,omitempty"`
+}
+
+// ChangeNamespace is the namespace of a change event.
+type ChangeNamespace struct {
+	Database   string `bson:"db"`
+	Collection string `bson:"coll,omitempty"`
+}
+
+// String returns the namespace in the form "database.collection", or the database name if there is no collection.
+func (ns ChangeNamespace) String() string {
+	if ns.Collection == "" {
+		return ns.Database
+	}
+	return ns.Database + "." + ns.Collection
+}
+
+// UpdateDescription describes the fields changed by an update event.
+type UpdateDescription struct {
+	// UpdatedFields maps the dotted paths of the updated fields to their new values.
+	UpdatedFields bson.Raw `bson:"updatedFields"`
+
+	// RemovedFields holds the dotted paths of the removed fields.
+	RemovedFields []string `bson:"removedFields"`
+
+	// TruncatedArrays holds the arrays that were shortened by the update.
+	TruncatedArrays []TruncatedArray `bson:"truncatedArrays,omitempty"`
+
+	// DisambiguatedPaths maps the paths in UpdatedFields and RemovedFields that are ambiguous, because they contain
+	// dots in field names or numeric field names, to arrays of their path components. It is only reported if the
+	// ShowExpandedEvents option is set.
+	DisambiguatedPaths bson.Raw `bson:"disambiguatedPaths,omitempty"`
+}
+
+// TruncatedArray describes an array that was shortened by an update.
+type TruncatedArray struct {
+	// Field is the dotted path of the array.
+	Field string `bson:"field"`
+
+	// NewSize is the number of elements in the array after the update.
+	NewSize int32 `bson:"newSize"`
+}
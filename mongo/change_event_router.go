@@ -0,0 +1,220 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+)
+
+// ChangeEventHandler handles a typed change event.
+type ChangeEventHandler[T any] func(ctx context.Context, event *ChangeEvent[T]) error
+
+// ChangeEventRouter dispatches change events to the handlers registered for their operation type and namespace.
+// Handlers must be registered with On before the router is used.
+type ChangeEventRouter[T any] struct {
+	routes             []changeEventRoute[T]
+	concurrency        int
+	orderByDocumentKey bool
+}
+
+// changeEventRoute is a handler and the events it is registered for.
+type changeEventRoute[T any] struct {
+	opType    OperationType
+	namespace string
+	handler   ChangeEventHandler[T]
+}
+
+// matches returns true if the route is registered for event.
+func (r changeEventRoute[T]) matches(event *ChangeEvent[T]) bool {
+	if r.opType != "" && r.opType != event.OperationType {
+		return false
+	}
+	if r.namespace == "" {
+		return true
+	}
+	if strings.Contains(r.namespace, ".") {
+		return r.namespace == event.Namespace.String()
+	}
+	return r.namespace == event.Namespace.Database
+}
+
+// routedEvent is an event sent to a worker of ChangeEventRouter.Run. If barrier is not nil, the worker calls Done on it
+// instead of handling an event.
+type routedEvent[T any] struct {
+	event   *ChangeEvent[T]
+	barrier *sync.WaitGroup
+}
+
+// NewChangeEventRouter creates a ChangeEventRouter without any handlers.
+//
+// The opts parameter can be used to specify options for the router (see the options.ChangeEventRouterOptions
+// documentation).
+func NewChangeEventRouter[T any](opts ...*options.ChangeEventRouterOptions) (*ChangeEventRouter[T], error) {
+	cero := options.MergeChangeEventRouterOptions(opts...)
+
+	r := &ChangeEventRouter[T]{concurrency: 1, orderByDocumentKey: true}
+	if cero.Concurrency != nil {
+		if *cero.Concurrency <= 0 {
+			return nil, fmt.Errorf("concurrency must be positive, but was %d", *cero.Concurrency)
+		}
+		r.concurrency = *cero.Concurrency
+	}
+	if cero.OrderByDocumentKey != nil {
+		r.orderByDocumentKey = *cero.OrderByDocumentKey
+	}
+	return r, nil
+}
+
+// On registers handler for the events with the operation type opType in namespace. An empty opType matches every
+// operation type. The namespace can be "database.collection", "database" to match every collection of a database, or
+// "" to match every namespace. If several handlers match an event, they are called in the order in which they were
+// registered.
+func (r *ChangeEventRouter[T]) On(opType OperationType, namespace string,
+	handler ChangeEventHandler[T]) *ChangeEventRouter[T] {
+
+	r.routes = append(r.routes, changeEventRoute[T]{opType: opType, namespace: namespace, handler: handler})
+	return r
+}
+
+// Dispatch calls the handlers that match event and returns the first error. Events that match no handler are ignored.
+func (r *ChangeEventRouter[T]) Dispatch(ctx context.Context, event *ChangeEvent[T]) error {
+	for _, route := range r.routes {
+		if !route.matches(event) {
+			continue
+		}
+		if err := route.handler(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChangeStreamHandler returns a ChangeStreamHandler that decodes each event into a ChangeEvent and dispatches it. The
+// events are handled one at a time, so the returned handler can be used with a ChangeStreamConsumer, which only
+// checkpoints an event once it has been handled.
+func (r *ChangeEventRouter[T]) ChangeStreamHandler() ChangeStreamHandler {
+	return func(ctx context.Context, raw bson.Raw) error {
+		event := new(ChangeEvent[T])
+		if err := bson.Unmarshal(raw, event); err != nil {
+			return err
+		}
+		return r.Dispatch(ctx, event)
+	}
+}
+
+// Run dispatches the events of cs until ctx is done, a handler returns an error, or the change stream fails or is
+// invalidated. Up to the Concurrency option events are handled concurrently. The first error returned by a handler
+// stops Run, which waits for the events being handled and returns the error. Run returns nil if the change stream is
+// invalidated. It does not close cs.
+func (r *ChangeEventRouter[T]) Run(ctx context.Context, cs *ChangeStream) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var errOnce sync.Once
+	var runErr error
+	fail := func(err error) {
+		errOnce.Do(func() {
+			runErr = err
+			cancel()
+		})
+	}
+
+	workers := make([]chan routedEvent[T], r.concurrency)
+	var wg sync.WaitGroup
+	for i := range workers {
+		workers[i] = make(chan routedEvent[T], 1)
+		wg.Add(1)
+		go func(events <-chan routedEvent[T]) {
+			defer wg.Done()
+			for re := range events {
+				if re.barrier != nil {
+					re.barrier.Done()
+					continue
+				}
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := r.Dispatch(ctx, re.event); err != nil {
+					fail(err)
+				}
+			}
+		}(workers[i])
+	}
+
+	send := func(worker int, re routedEvent[T]) bool {
+		select {
+		case workers[worker] <- re:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	var next int
+	for cs.Next(ctx) {
+		event := new(ChangeEvent[T])
+		if err := cs.Decode(event); err != nil {
+			fail(err)
+			break
+		}
+
+		if !r.orderByDocumentKey {
+			if !send(next, routedEvent[T]{event: event}) {
+				break
+			}
+			next = (next + 1) % len(workers)
+			continue
+		}
+		if event.DocumentKey != nil {
+			h := fnv.New32a()
+			_, _ = h.Write(event.DocumentKey)
+			if !send(int(h.Sum32()%uint32(len(workers))), routedEvent[T]{event: event}) {
+				break
+			}
+			continue
+		}
+
+		// Events without a documentKey wait for every previous event to be handled.
+		barrier := new(sync.WaitGroup)
+		barrier.Add(len(workers))
+		sent := true
+		for i := range workers {
+			if !send(i, routedEvent[T]{barrier: barrier}) {
+				sent = false
+				break
+			}
+		}
+		if !sent {
+			break
+		}
+		barrier.Wait()
+		if ctx.Err() != nil {
+			break
+		}
+		if err := r.Dispatch(ctx, event); err != nil {
+			fail(err)
+			break
+		}
+	}
+
+	for _, w := range workers {
+		close(w)
+	}
+	wg.Wait()
+
+	if runErr != nil {
+		return runErr
+	}
+	return cs.Err()
+}
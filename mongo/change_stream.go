@@ -33,6 +33,8 @@ var (
 	ErrMissingResumeToken = errors.New("cannot provide resume functionality when the resume token is missing")
 	// ErrNilCursor indicates that the underlying cursor for the change stream is nil.
 	ErrNilCursor = errors.New("cursor is nil")
+	// ErrMissingEventFragment indicates that the fragments of a split change stream event were not received in order.
+	ErrMissingEventFragment = errors.New("change stream event fragment is missing")
 
 	minResumableLabelWireVersion int32 = 9 // Wire version at which the server includes the resumable error label
 	networkErrorLabel                  = "NetworkError"
@@ -87,6 +89,7 @@ type ChangeStream struct {
 	selector        description.ServerSelector
 	operationTime   *primitive.Timestamp
 	wireVersion     *description.VersionRange
+	fragments       []bson.Raw
 }
 
 type changeStreamConfig struct {
@@ -136,7 +139,8 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 	cs.aggregate = operation.NewAggregate(nil).
 		ReadPreference(config.readPreference).ReadConcern(config.readConcern).
 		Deployment(cs.client.deployment).ClusterClock(cs.client.clock).
-		CommandMonitor(cs.client.monitor).Session(cs.sess).ServerSelector(cs.selector).Retry(driver.RetryNone).
+		CommandMonitor(cs.client.monitor).
+		Interceptors(cs.client.interceptors).Session(cs.sess).ServerSelector(cs.selector).Retry(driver.RetryNone).
 		ServerAPI(cs.client.serverAPI).Crypt(config.crypt).Timeout(cs.client.timeout)
 
 	if cs.options.Collation != nil {
@@ -257,6 +261,9 @@ func (cs *ChangeStream) executeOperation(ctx context.Context, resuming bool) err
 	cs.aggregate.Deployment(cs.createOperationDeployment(server, conn))
 
 	if resuming {
+		// The resume token is the one of the last complete event, so the server sends every fragment of a split
+		// event again.
+		cs.fragments = nil
 		cs.replaceOptions(cs.wireVersion)
 
 		csOptDoc, err := cs.createPipelineOptionsDoc()
@@ -364,8 +371,9 @@ AggregateExecuteLoop:
 
 // Updates the post batch resume token after a successful aggregate or getMore operation.
 func (cs *ChangeStream) updatePbrtFromCommand() {
-	// Only cache the pbrt if an empty batch was returned and a pbrt was included
-	if pbrt := cs.cursor.PostBatchResumeToken(); cs.emptyBatch() && pbrt != nil {
+	// Only cache the pbrt if an empty batch was returned and a pbrt was included. A pbrt received while a split event
+	// is incomplete would skip the fragments that have already been received.
+	if pbrt := cs.cursor.PostBatchResumeToken(); cs.emptyBatch() && pbrt != nil && len(cs.fragments) == 0 {
 		cs.resumeToken = bson.Raw(pbrt)
 	}
 }
@@ -425,6 +433,12 @@ func (cs *ChangeStream) buildPipelineSlice(pipeline interface{}) error {
 		cs.pipelineSlice = append(cs.pipelineSlice, elem)
 	}
 
+	if cs.options.ReassembleSplitEvents != nil && *cs.options.ReassembleSplitEvents {
+		cs.pipelineSlice = append(cs.pipelineSlice, bsoncore.NewDocumentBuilder().
+			AppendDocument("$changeStreamSplitLargeEvent", bsoncore.NewDocumentBuilder().Build()).
+			Build())
+	}
+
 	return cs.err
 }
 
@@ -633,24 +647,86 @@ func (cs *ChangeStream) next(ctx context.Context, nonBlocking bool) bool {
 		ctx = context.Background()
 	}
 
-	if len(cs.batch) == 0 {
-		cs.loopNext(ctx, nonBlocking)
-		if cs.err != nil {
-			cs.err = replaceErrors(cs.err)
-			return false
-		}
+	for {
 		if len(cs.batch) == 0 {
+			cs.loopNext(ctx, nonBlocking)
+			if cs.err != nil {
+				cs.err = replaceErrors(cs.err)
+				return false
+			}
+			if len(cs.batch) == 0 {
+				return false
+			}
+		}
+
+		// successfully got non-empty batch
+		cs.Current = bson.Raw(cs.batch[0])
+		cs.batch = cs.batch[1:]
+		if cs.options.ReassembleSplitEvents != nil && *cs.options.ReassembleSplitEvents {
+			var complete bool
+			if complete, cs.err = cs.addFragment(); cs.err != nil {
+				return false
+			}
+			if !complete {
+				continue
+			}
+		}
+		if cs.err = cs.storeResumeToken(); cs.err != nil {
 			return false
 		}
+		return true
 	}
+}
 
-	// successfully got non-empty batch
-	cs.Current = bson.Raw(cs.batch[0])
-	cs.batch = cs.batch[1:]
-	if cs.err = cs.storeResumeToken(); cs.err != nil {
-		return false
+// addFragment buffers Current if it is a fragment of a split event. It returns true if Current is a complete event,
+// either because it was not split or because it is the last fragment, in which case Current is replaced by the event
+// merged from all of the fragments.
+func (cs *ChangeStream) addFragment() (bool, error) {
+	split, ok := cs.Current.Lookup("splitEvent").DocumentOK()
+	if !ok {
+		if len(cs.fragments) > 0 {
+			return false, ErrMissingEventFragment
+		}
+		return true, nil
+	}
+	fragment, ok := split.Lookup("fragment").AsInt64OK()
+	if !ok || fragment != int64(len(cs.fragments)+1) {
+		return false, ErrMissingEventFragment
+	}
+	of, ok := split.Lookup("of").AsInt64OK()
+	if !ok || fragment > of {
+		return false, ErrMissingEventFragment
+	}
+
+	// The batch may be overwritten by the next getMore, so the fragments are copied.
+	cs.fragments = append(cs.fragments, append(bson.Raw(nil), cs.Current...))
+	if fragment < of {
+		return false, nil
+	}
+
+	// Each fragment holds a subset of the fields of the event and its own resume token. The merged event has the
+	// resume token of the last fragment.
+	id, ok := cs.Current.Lookup("_id").DocumentOK()
+	if !ok {
+		_ = cs.Close(context.Background())
+		return false, ErrMissingResumeToken
+	}
+	merged := bsoncore.NewDocumentBuilder().AppendDocument("_id", id)
+	for _, f := range cs.fragments {
+		elems, err := f.Elements()
+		if err != nil {
+			return false, err
+		}
+		for _, elem := range elems {
+			if key := elem.Key(); key == "_id" || key == "splitEvent" {
+				continue
+			}
+			merged.AppendValue(elem.Key(), bsoncore.Value{Type: elem.Value().Type, Data: elem.Value().Value})
+		}
 	}
-	return true
+	cs.fragments = nil
+	cs.Current = bson.Raw(merged.Build())
+	return true, nil
 }
 
 func (cs *ChangeStream) loopNext(ctx context.Context, nonBlocking bool) {
@@ -0,0 +1,230 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+)
+
+const (
+	defaultCheckpointEvents   = 100
+	defaultCheckpointInterval = 5 * time.Second
+)
+
+// ChangeStreamWatcher is the interface implemented by Client, Database and Collection to open a change stream.
+type ChangeStreamWatcher interface {
+	Watch(ctx context.Context, pipeline interface{}, opts ...*options.ChangeStreamOptions) (*ChangeStream, error)
+}
+
+// ChangeStreamHandler processes a change stream event. The event is only valid until the handler returns; a copy must
+// be made if continued access is required. Returning nil acknowledges the event.
+type ChangeStreamHandler func(ctx context.Context, event bson.Raw) error
+
+// ChangeStreamConsumer processes the events of a change stream with a handler and saves its position in a
+// CheckpointStore, so that processing resumes where it stopped when the consumer is restarted.
+//
+// Events are processed at least once: a checkpoint only covers events that the handler has acknowledged, but the
+// events acknowledged after the last checkpoint are processed again after a restart, so the handler must be
+// idempotent.
+type ChangeStreamConsumer struct {
+	watcher            ChangeStreamWatcher
+	id                 string
+	store              CheckpointStore
+	handler            ChangeStreamHandler
+	pipeline           interface{}
+	csOpts             *options.ChangeStreamOptions
+	checkpointEvents   int
+	checkpointInterval time.Duration
+	historyLostPolicy  options.HistoryLostPolicy
+	onHistoryLost      func(error)
+}
+
+// NewChangeStreamConsumer creates a ChangeStreamConsumer that watches watcher, which can be a Client, Database or
+// Collection, and passes each event to handler. The checkpoints of the consumer are saved in store under id, which
+// must be unique among the consumers that share the store.
+//
+// The opts parameter can be used to specify options for the consumer (see the options.ChangeStreamConsumerOptions
+// documentation).
+func NewChangeStreamConsumer(watcher ChangeStreamWatcher, id string, store CheckpointStore,
+	handler ChangeStreamHandler, opts ...*options.ChangeStreamConsumerOptions) (*ChangeStreamConsumer, error) {
+
+	csco := options.MergeChangeStreamConsumerOptions(opts...)
+	c := &ChangeStreamConsumer{
+		watcher:            watcher,
+		id:                 id,
+		store:              store,
+		handler:            handler,
+		pipeline:           csco.Pipeline,
+		csOpts:             csco.ChangeStreamOptions,
+		checkpointEvents:   defaultCheckpointEvents,
+		checkpointInterval: defaultCheckpointInterval,
+		historyLostPolicy:  options.HistoryLostFail,
+		onHistoryLost:      csco.OnHistoryLost,
+	}
+	if c.pipeline == nil {
+		c.pipeline = Pipeline{}
+	}
+	if csco.CheckpointEvents != nil {
+		if *csco.CheckpointEvents <= 0 {
+			return nil, fmt.Errorf("checkpoint events must be positive, but was %d", *csco.CheckpointEvents)
+		}
+		c.checkpointEvents = *csco.CheckpointEvents
+	}
+	if csco.CheckpointInterval != nil {
+		if *csco.CheckpointInterval <= 0 {
+			return nil, fmt.Errorf("checkpoint interval must be positive, but was %v", *csco.CheckpointInterval)
+		}
+		c.checkpointInterval = *csco.CheckpointInterval
+	}
+	if csco.HistoryLostPolicy != nil {
+		switch p := *csco.HistoryLostPolicy; p {
+		case options.HistoryLostFail, options.HistoryLostRestart:
+			c.historyLostPolicy = p
+		default:
+			return nil, fmt.Errorf("unknown history lost policy %q", p)
+		}
+	}
+	return c, nil
+}
+
+// Run loads the last checkpoint, opens the change stream after it and processes events until ctx is done, the handler
+// returns an error, or the change stream fails or is invalidated. Before returning, Run saves a checkpoint of the
+// events acknowledged so far.
+//
+// If the checkpoint can no longer be resumed from, the HistoryLostPolicy option decides whether Run returns the error
+// or restarts the change stream from the current time. Run returns nil after an invalidate event has been acknowledged
+// and checkpointed; calling Run again starts after the invalidate event.
+func (c *ChangeStreamConsumer) Run(ctx context.Context) error {
+	restart := false
+	for {
+		var cp *Checkpoint
+		if !restart {
+			var err error
+			cp, err = c.store.Load(ctx, c.id)
+			if err != nil {
+				return err
+			}
+		}
+
+		cs, err := c.watcher.Watch(ctx, c.pipeline, c.streamOptions(cp, restart))
+		if err == nil {
+			err = c.consume(ctx, cs)
+			_ = cs.Close(newBackgroundContext(ctx))
+		}
+		if !isHistoryLostError(err) {
+			return err
+		}
+
+		if c.onHistoryLost != nil {
+			c.onHistoryLost(err)
+		}
+		if c.historyLostPolicy != options.HistoryLostRestart {
+			return err
+		}
+		restart = true
+	}
+}
+
+// streamOptions returns the options used to open the change stream after cp. If restart is true, the change stream is
+// opened at the current time.
+func (c *ChangeStreamConsumer) streamOptions(cp *Checkpoint, restart bool) *options.ChangeStreamOptions {
+	opts := options.MergeChangeStreamOptions(c.csOpts)
+	if cp == nil && !restart {
+		return opts
+	}
+
+	opts.ResumeAfter, opts.StartAfter, opts.StartAtOperationTime = nil, nil, nil
+	switch {
+	case cp == nil:
+	case cp.Invalidated:
+		opts.StartAfter = cp.ResumeToken
+	default:
+		opts.ResumeAfter = cp.ResumeToken
+	}
+	return opts
+}
+
+// consume passes the events of cs to the handler and saves checkpoints until an error occurs or the change stream ends.
+func (c *ChangeStreamConsumer) consume(ctx context.Context, cs *ChangeStream) error {
+	var token, saved bson.Raw
+	var acked int
+	lastSave := time.Now()
+
+	// checkpoint saves token if it has changed since the last checkpoint.
+	checkpoint := func(ctx context.Context, invalidated bool) error {
+		if token == nil || bytes.Equal(token, saved) {
+			return nil
+		}
+		cp := &Checkpoint{ResumeToken: token, Invalidated: invalidated, SavedAt: time.Now()}
+		if err := c.store.Save(ctx, c.id, cp); err != nil {
+			return err
+		}
+		saved, acked, lastSave = token, 0, time.Now()
+		return nil
+	}
+	// stop saves a final checkpoint and returns err.
+	stop := func(err error, invalidated bool) error {
+		if cerr := checkpoint(newBackgroundContext(ctx), invalidated); err == nil {
+			err = cerr
+		}
+		return err
+	}
+
+	for {
+		if cs.TryNext(ctx) {
+			if err := c.handler(ctx, cs.Current); err != nil {
+				return stop(err, false)
+			}
+			acked++
+
+			if opType, _ := cs.Current.Lookup("operationType").StringValueOK(); opType == "invalidate" {
+				id, ok := cs.Current.Lookup("_id").DocumentOK()
+				if !ok {
+					return stop(errors.New("invalidate event has no _id"), false)
+				}
+				token = bson.Raw(append([]byte(nil), id...))
+				return stop(nil, true)
+			}
+			token = append(token[:0:0], cs.ResumeToken()...)
+		} else {
+			if err := cs.Err(); err != nil {
+				return stop(err, false)
+			}
+			if cs.ID() == 0 {
+				return stop(nil, false)
+			}
+			// The batch was empty, so the resume token is the post batch resume token.
+			if rt := cs.ResumeToken(); rt != nil {
+				token = append(token[:0:0], rt...)
+			}
+		}
+
+		if acked >= c.checkpointEvents || time.Since(lastSave) >= c.checkpointInterval {
+			if err := checkpoint(ctx, false); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// isHistoryLostError returns true if err indicates that a change stream cannot be resumed from its resume token.
+func isHistoryLostError(err error) bool {
+	var se ServerError
+	if !errors.As(err, &se) {
+		return false
+	}
+	return se.HasErrorCode(286) || // ChangeStreamHistoryLost
+		se.HasErrorCode(280) || // ChangeStreamFatalError, returned if the resume token is not found.
+		se.HasErrorCode(260) // InvalidResumeToken
+}
@@ -0,0 +1,150 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+)
+
+// Checkpoint is a position in a change stream saved by a ChangeStreamConsumer.
+type Checkpoint struct {
+	// ResumeToken is the resume token of the last acknowledged event, or the post batch resume token if the change
+	// stream was idle.
+	ResumeToken bson.Raw `bson:"resumeToken"`
+
+	// Invalidated is true if ResumeToken is the token of an invalidate event. Such a token can only be resumed from
+	// with the StartAfter option.
+	Invalidated bool `bson:"invalidated"`
+
+	// SavedAt is the time at which the checkpoint was saved.
+	SavedAt time.Time `bson:"savedAt"`
+}
+
+// CheckpointStore is the storage used by a ChangeStreamConsumer to persist its checkpoints. The checkpoints of
+// different consumers are identified by the ID of the consumer. Implementations must be safe for concurrent use by
+// multiple goroutines.
+type CheckpointStore interface {
+	// Load returns the last checkpoint saved for id, or nil if there is none.
+	Load(ctx context.Context, id string) (*Checkpoint, error)
+
+	// Save replaces the checkpoint of id.
+	Save(ctx context.Context, id string, cp *Checkpoint) error
+}
+
+// FileCheckpointStore is a CheckpointStore that saves each checkpoint in an Extended JSON file in a directory. The
+// files are replaced atomically, so a crash while saving leaves the previous checkpoint in place.
+type FileCheckpointStore struct {
+	dir string
+}
+
+var _ CheckpointStore = (*FileCheckpointStore)(nil)
+
+// NewFileCheckpointStore creates a FileCheckpointStore that saves checkpoints in dir. The directory is created when the
+// first checkpoint is saved if it does not exist.
+func NewFileCheckpointStore(dir string) *FileCheckpointStore {
+	return &FileCheckpointStore{dir: dir}
+}
+
+// Load implements the CheckpointStore interface.
+func (s *FileCheckpointStore) Load(_ context.Context, id string) (*Checkpoint, error) {
+	data, err := os.ReadFile(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cp Checkpoint
+	if err := bson.UnmarshalExtJSON(data, true, &cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// Save implements the CheckpointStore interface.
+func (s *FileCheckpointStore) Save(_ context.Context, id string, cp *Checkpoint) error {
+	data, err := bson.MarshalExtJSON(cp, true, false)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(s.dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path(id))
+}
+
+// path returns the path of the file that holds the checkpoint of id.
+func (s *FileCheckpointStore) path(id string) string {
+	return filepath.Join(s.dir, url.PathEscape(id)+".json")
+}
+
+// CollectionCheckpointStore is a CheckpointStore that saves checkpoints in a collection, with one document per
+// consumer whose _id is the ID of the consumer.
+type CollectionCheckpointStore struct {
+	coll *Collection
+}
+
+var _ CheckpointStore = (*CollectionCheckpointStore)(nil)
+
+// checkpointDocument is the document in which a CollectionCheckpointStore saves a checkpoint.
+type checkpointDocument struct {
+	ID         string `bson:"_id"`
+	Checkpoint `bson:",inline"`
+}
+
+// NewCollectionCheckpointStore creates a CollectionCheckpointStore that saves checkpoints in coll. The collection
+// should use a majority write concern so that checkpoints survive a failover.
+func NewCollectionCheckpointStore(coll *Collection) *CollectionCheckpointStore {
+	return &CollectionCheckpointStore{coll: coll}
+}
+
+// Load implements the CheckpointStore interface.
+func (s *CollectionCheckpointStore) Load(ctx context.Context, id string) (*Checkpoint, error) {
+	var doc checkpointDocument
+	err := s.coll.FindOne(ctx, bson.D{{"_id", id}}).Decode(&doc)
+	if errors.Is(err, ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &doc.Checkpoint, nil
+}
+
+// Save implements the CheckpointStore interface.
+func (s *CollectionCheckpointStore) Save(ctx context.Context, id string, cp *Checkpoint) error {
+	doc := checkpointDocument{ID: id, Checkpoint: *cp}
+	_, err := s.coll.ReplaceOne(ctx, bson.D{{"_id", id}}, doc, options.Replace().SetUpsert(true))
+	return err
+}
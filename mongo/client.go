@@ -64,8 +64,10 @@ type Client struct {
 	bsonOpts       *options.BSONOptions
 	registry       *bsoncodec.Registry
 	monitor        *event.CommandMonitor
+	interceptors   []event.CommandInterceptor
 	serverAPI      *driver.ServerAPIOptions
 	serverMonitor  *event.ServerMonitor
+	txnMonitor     *event.TransactionMonitor
 	sessionPool    *session.Pool
 	timeout        *time.Duration
 	httpClient     *http.Client
@@ -151,10 +153,14 @@ func NewClient(opts ...*options.ClientOptions) (*Client, error) {
 	if clientOpt.Monitor != nil {
 		client.monitor = clientOpt.Monitor
 	}
+	// Interceptors
+	client.interceptors = clientOpt.Interceptors
 	// ServerMonitor
 	if clientOpt.ServerMonitor != nil {
 		client.serverMonitor = clientOpt.ServerMonitor
 	}
+	// TransactionMonitor
+	client.txnMonitor = clientOpt.TransactionMonitor
 	// ReadConcern
 	client.readConcern = readconcern.New()
 	if clientOpt.ReadConcern != nil {
@@ -429,6 +435,7 @@ func (c *Client) endSessions(ctx context.Context) {
 	sessionIDs := c.sessionPool.IDSlice()
 	op := operation.NewEndSessions(nil).ClusterClock(c.clock).Deployment(c.deployment).
 		ServerSelector(description.ReadPrefSelector(readpref.PrimaryPreferred())).CommandMonitor(c.monitor).
+		Interceptors(c.interceptors).
 		Database("admin").Crypt(c.cryptFLE).ServerAPI(c.serverAPI)
 
 	totalNumIDs := len(sessionIDs)
@@ -688,7 +695,7 @@ func (c *Client) ListDatabases(ctx context.Context, filter interface{}, opts ...
 
 	ldo := options.MergeListDatabasesOptions(opts...)
 	op := operation.NewListDatabases(filterDoc).
-		Session(sess).ReadPreference(c.readPreference).CommandMonitor(c.monitor).
+		Session(sess).ReadPreference(c.readPreference).CommandMonitor(c.monitor).Interceptors(c.interceptors).
 		ServerSelector(selector).ClusterClock(c.clock).Database("admin").Deployment(c.deployment).Crypt(c.cryptFLE).
 		ServerAPI(c.serverAPI).Timeout(c.timeout)
 
@@ -832,6 +839,7 @@ func (c *Client) Timeout() *time.Duration {
 func (c *Client) createBaseCursorOptions() driver.CursorOptions {
 	return driver.CursorOptions{
 		CommandMonitor: c.monitor,
+		Interceptors:   c.interceptors,
 		Crypt:          c.cryptFLE,
 		ServerAPI:      c.serverAPI,
 	}
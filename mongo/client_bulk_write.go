@@ -0,0 +1,418 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/bsoncodec"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/bsontype"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/primitive"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/description"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/writeconcern"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver/operation"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver/session"
+)
+
+// BulkWrite performs a bulk write operation that can target multiple namespaces using the bulkWrite command. This
+// requires MongoDB version 8.0 or higher.
+//
+// The writes parameter must be a slice of ClientBulkWrite values, each of which specifies the database and collection
+// targeted by the write and the write model to execute. The slice cannot be empty and the models must all be non-nil.
+// The writes are split into as many bulkWrite commands as needed to respect the server's maxWriteBatchSize and
+// maxMessageSizeBytes limits.
+//
+// The opts parameter can be used to specify options for the operation (see the options.ClientBulkWriteOptions
+// documentation).
+//
+// If some writes fail, the returned error will be of type ClientBulkWriteException and its PartialResult field will
+// contain the result of the writes that were executed.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/bulkWrite/.
+func (c *Client) BulkWrite(ctx context.Context, writes []ClientBulkWrite,
+	opts ...*options.ClientBulkWriteOptions) (*ClientBulkWriteResult, error) {
+
+	if len(writes) == 0 {
+		return nil, ErrEmptySlice
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sess := sessionFromContext(ctx)
+	if sess == nil && c.sessionPool != nil {
+		sess = session.NewImplicitClientSession(c.sessionPool, c.id)
+		defer sess.EndSession()
+	}
+
+	err := c.validSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	cbwo := options.MergeClientBulkWriteOptions(opts...)
+
+	wc := c.writeConcern
+	if cbwo.WriteConcern != nil {
+		wc = cbwo.WriteConcern
+	}
+	if sess.TransactionRunning() {
+		wc = nil
+	}
+	if !writeconcern.AckWrite(wc) {
+		if cbwo.Ordered != nil && *cbwo.Ordered {
+			return nil, errors.New("ordered writes cannot be used with an unacknowledged write concern")
+		}
+		sess = nil
+	}
+
+	selector := makePinnedSelector(sess, description.CompositeSelector([]description.ServerSelector{
+		description.WriteSelector(),
+		description.LatencySelector(c.localThreshold),
+	}))
+
+	ops := make([]operation.ClientBulkWriteOp, len(writes))
+	insertedIDs := make(map[int]interface{})
+	canRetry := true
+	for i, write := range writes {
+		if write.Model == nil {
+			return nil, ErrNilDocument
+		}
+		if write.Database == "" || write.Collection == "" {
+			return nil, fmt.Errorf("write at index %d must specify a database and a collection", i)
+		}
+
+		ops[i], err = c.newClientBulkWriteOp(write)
+		if err != nil {
+			return nil, err
+		}
+
+		switch write.Model.(type) {
+		case *ClientInsertOneModel:
+			id, err := ops[i].Fields.LookupErr("document", "_id")
+			if err != nil {
+				return nil, err
+			}
+			insertedIDs[i], err = c.unmarshalBulkWriteValue(id)
+			if err != nil {
+				return nil, err
+			}
+		case *ClientUpdateManyModel, *ClientDeleteManyModel:
+			canRetry = false
+		}
+	}
+
+	op := operation.NewClientBulkWrite(ops).
+		Session(sess).WriteConcern(wc).CommandMonitor(c.monitor).Interceptors(c.interceptors).
+		ServerSelector(selector).ClusterClock(c.clock).
+		Deployment(c.deployment).Crypt(c.cryptFLE).
+		ServerAPI(c.serverAPI).Timeout(c.timeout).Logger(c.logger).
+		// Individual results are always requested so that the per-namespace counts can be computed.
+		ErrorsOnly(false)
+	// An unacknowledged bulk write cannot report where an ordered write stopped, so its writes are unordered by
+	// default.
+	switch {
+	case cbwo.Ordered != nil:
+		op = op.Ordered(*cbwo.Ordered)
+	case !writeconcern.AckWrite(wc):
+		op = op.Ordered(false)
+	}
+	if cbwo.BypassDocumentValidation != nil && *cbwo.BypassDocumentValidation {
+		op = op.BypassDocumentValidation(*cbwo.BypassDocumentValidation)
+	}
+	if cbwo.Comment != nil {
+		comment, err := marshalValue(cbwo.Comment, c.bsonOpts, c.registry)
+		if err != nil {
+			return nil, err
+		}
+		op = op.Comment(comment)
+	}
+	if cbwo.Let != nil {
+		let, err := marshal(cbwo.Let, c.bsonOpts, c.registry)
+		if err != nil {
+			return nil, err
+		}
+		op = op.Let(let)
+	}
+
+	retry := driver.RetryNone
+	if c.retryWrites && canRetry {
+		retry = driver.RetryOncePerCommand
+	}
+	op = op.Retry(retry)
+
+	err = op.Execute(ctx)
+	if !writeconcern.AckWrite(wc) {
+		if err != nil {
+			return nil, replaceErrors(err)
+		}
+		return &ClientBulkWriteResult{Acknowledged: false}, ErrUnacknowledgedWrite
+	}
+
+	res := op.Result()
+	if err != nil && res.Processed == 0 {
+		return nil, replaceErrors(err)
+	}
+
+	verbose := cbwo.VerboseResults != nil && *cbwo.VerboseResults
+	result, exception, convErr := c.newClientBulkWriteResult(writes, ops, insertedIDs, res, verbose)
+	if convErr != nil {
+		return nil, convErr
+	}
+	if err != nil {
+		exception.TopLevelError = replaceErrors(err)
+	}
+	if exception.TopLevelError != nil || len(exception.WriteErrors) > 0 || len(exception.WriteConcernErrors) > 0 {
+		exception.PartialResult = result
+		return result, exception
+	}
+	return result, nil
+}
+
+// newClientBulkWriteOp converts the given write into a write for the ClientBulkWrite operation.
+func (c *Client) newClientBulkWriteOp(write ClientBulkWrite) (operation.ClientBulkWriteOp, error) {
+	op := operation.ClientBulkWriteOp{Namespace: write.Database + "." + write.Collection}
+
+	var err error
+	switch model := write.Model.(type) {
+	case *ClientInsertOneModel:
+		var doc bsoncore.Document
+		doc, err = marshal(model.Document, c.bsonOpts, c.registry)
+		if err != nil {
+			return op, err
+		}
+		doc, _, err = ensureID(doc, primitive.NilObjectID, c.bsonOpts, c.registry)
+		if err != nil {
+			return op, err
+		}
+
+		op.Kind = "insert"
+		idx, fields := bsoncore.AppendDocumentStart(nil)
+		fields = bsoncore.AppendDocumentElement(fields, "document", doc)
+		op.Fields, err = bsoncore.AppendDocumentEnd(fields, idx)
+	case *ClientUpdateOneModel:
+		op.Kind = "update"
+		op.Fields, err = createClientUpdateDoc(model.Filter, model.Update, model.Hint, model.ArrayFilters,
+			model.Collation, model.Upsert, false, true, c.bsonOpts, c.registry)
+	case *ClientUpdateManyModel:
+		op.Kind = "update"
+		op.Fields, err = createClientUpdateDoc(model.Filter, model.Update, model.Hint, model.ArrayFilters,
+			model.Collation, model.Upsert, true, true, c.bsonOpts, c.registry)
+	case *ClientReplaceOneModel:
+		op.Kind = "update"
+		op.Fields, err = createClientUpdateDoc(model.Filter, model.Replacement, model.Hint, nil, model.Collation,
+			model.Upsert, false, false, c.bsonOpts, c.registry)
+	case *ClientDeleteOneModel:
+		op.Kind = "delete"
+		op.Fields, err = createClientDeleteDoc(model.Filter, model.Collation, model.Hint, false, c.bsonOpts,
+			c.registry)
+	case *ClientDeleteManyModel:
+		op.Kind = "delete"
+		op.Fields, err = createClientDeleteDoc(model.Filter, model.Collation, model.Hint, true, c.bsonOpts,
+			c.registry)
+	default:
+		err = fmt.Errorf("unsupported client write model type %T", write.Model)
+	}
+	return op, err
+}
+
+// newClientBulkWriteResult builds the result and the exception of a Client.BulkWrite operation from the result of
+// the ClientBulkWrite operation.
+func (c *Client) newClientBulkWriteResult(
+	writes []ClientBulkWrite,
+	ops []operation.ClientBulkWriteOp,
+	insertedIDs map[int]interface{},
+	res operation.ClientBulkWriteResult,
+	verbose bool,
+) (*ClientBulkWriteResult, ClientBulkWriteException, error) {
+	result := &ClientBulkWriteResult{
+		InsertedCount:    res.NInserted,
+		MatchedCount:     res.NMatched,
+		ModifiedCount:    res.NModified,
+		DeletedCount:     res.NDeleted,
+		UpsertedCount:    res.NUpserted,
+		NamespaceResults: make(map[string]ClientBulkWriteNamespaceResult),
+		Acknowledged:     true,
+	}
+	if verbose {
+		result.InsertResults = make(map[int]ClientInsertResult)
+		result.UpdateResults = make(map[int]ClientUpdateResult)
+		result.DeleteResults = make(map[int]ClientDeleteResult)
+	}
+
+	var exception ClientBulkWriteException
+	for _, wce := range res.WriteConcernErrors {
+		wce := wce
+		exception.WriteConcernErrors = append(exception.WriteConcernErrors, *convertDriverWriteConcernError(&wce))
+	}
+
+	for _, r := range res.Results {
+		if r.Index < 0 || r.Index >= len(writes) {
+			return nil, exception, fmt.Errorf("server returned a result for write index %d, but only %d writes were sent",
+				r.Index, len(writes))
+		}
+
+		if !r.OK {
+			if exception.WriteErrors == nil {
+				exception.WriteErrors = make(map[int]WriteError)
+			}
+			exception.WriteErrors[r.Index] = WriteError{
+				Index:   r.Index,
+				Code:    int(r.Code),
+				Message: r.Message,
+				Details: bson.Raw(r.Details),
+				Raw:     bson.Raw(r.Raw),
+			}
+			continue
+		}
+
+		ns := ops[r.Index].Namespace
+		nsResult := result.NamespaceResults[ns]
+		switch ops[r.Index].Kind {
+		case "insert":
+			nsResult.InsertedCount += r.N
+			if verbose {
+				result.InsertResults[r.Index] = ClientInsertResult{InsertedID: insertedIDs[r.Index]}
+			}
+		case "update":
+			updateResult := ClientUpdateResult{MatchedCount: r.N, ModifiedCount: r.NModified}
+			if r.UpsertedID.Type != bsontype.Type(0) {
+				id, err := c.unmarshalBulkWriteValue(r.UpsertedID)
+				if err != nil {
+					return nil, exception, err
+				}
+				updateResult.UpsertedID = id
+				updateResult.MatchedCount--
+				nsResult.UpsertedCount++
+			}
+			nsResult.MatchedCount += updateResult.MatchedCount
+			nsResult.ModifiedCount += updateResult.ModifiedCount
+			if verbose {
+				result.UpdateResults[r.Index] = updateResult
+			}
+		case "delete":
+			nsResult.DeletedCount += r.N
+			if verbose {
+				result.DeleteResults[r.Index] = ClientDeleteResult{DeletedCount: r.N}
+			}
+		}
+		result.NamespaceResults[ns] = nsResult
+	}
+
+	return result, exception, nil
+}
+
+// unmarshalBulkWriteValue decodes an _id value into an empty interface using the client's registry.
+func (c *Client) unmarshalBulkWriteValue(val bsoncore.Value) (interface{}, error) {
+	var id interface{}
+	err := bson.RawValue{Type: val.Type, Value: val.Data}.UnmarshalWithRegistry(c.registry, &id)
+	return id, err
+}
+
+func createClientUpdateDoc(
+	filter interface{},
+	update interface{},
+	hint interface{},
+	arrayFilters *options.ArrayFilters,
+	collation *options.Collation,
+	upsert *bool,
+	multi bool,
+	checkDollarKey bool,
+	bsonOpts *options.BSONOptions,
+	registry *bsoncodec.Registry,
+) (bsoncore.Document, error) {
+	f, err := marshal(filter, bsonOpts, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	uidx, updateDoc := bsoncore.AppendDocumentStart(nil)
+	updateDoc = bsoncore.AppendDocumentElement(updateDoc, "filter", f)
+
+	u, err := marshalUpdateValue(update, bsonOpts, registry, checkDollarKey)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDoc = bsoncore.AppendValueElement(updateDoc, "updateMods", u)
+	updateDoc = bsoncore.AppendBooleanElement(updateDoc, "multi", multi)
+
+	if arrayFilters != nil {
+		reg := registry
+		if arrayFilters.Registry != nil {
+			reg = arrayFilters.Registry
+		}
+		arr, err := marshalValue(arrayFilters.Filters, bsonOpts, reg)
+		if err != nil {
+			return nil, err
+		}
+		updateDoc = bsoncore.AppendArrayElement(updateDoc, "arrayFilters", arr.Data)
+	}
+
+	if collation != nil {
+		updateDoc = bsoncore.AppendDocumentElement(updateDoc, "collation", bsoncore.Document(collation.ToDocument()))
+	}
+
+	if upsert != nil {
+		updateDoc = bsoncore.AppendBooleanElement(updateDoc, "upsert", *upsert)
+	}
+
+	if hint != nil {
+		if isUnorderedMap(hint) {
+			return nil, ErrMapForOrderedArgument{"hint"}
+		}
+		hintVal, err := marshalValue(hint, bsonOpts, registry)
+		if err != nil {
+			return nil, err
+		}
+		updateDoc = bsoncore.AppendValueElement(updateDoc, "hint", hintVal)
+	}
+
+	updateDoc, _ = bsoncore.AppendDocumentEnd(updateDoc, uidx)
+	return updateDoc, nil
+}
+
+func createClientDeleteDoc(
+	filter interface{},
+	collation *options.Collation,
+	hint interface{},
+	multi bool,
+	bsonOpts *options.BSONOptions,
+	registry *bsoncodec.Registry,
+) (bsoncore.Document, error) {
+	f, err := marshal(filter, bsonOpts, registry)
+	if err != nil {
+		return nil, err
+	}
+
+	didx, doc := bsoncore.AppendDocumentStart(nil)
+	doc = bsoncore.AppendDocumentElement(doc, "filter", f)
+	doc = bsoncore.AppendBooleanElement(doc, "multi", multi)
+	if collation != nil {
+		doc = bsoncore.AppendDocumentElement(doc, "collation", collation.ToDocument())
+	}
+	if hint != nil {
+		if isUnorderedMap(hint) {
+			return nil, ErrMapForOrderedArgument{"hint"}
+		}
+		hintVal, err := marshalValue(hint, bsonOpts, registry)
+		if err != nil {
+			return nil, err
+		}
+		doc = bsoncore.AppendValueElement(doc, "hint", hintVal)
+	}
+	doc, _ = bsoncore.AppendDocumentEnd(doc, didx)
+
+	return doc, nil
+}
@@ -287,7 +287,7 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 	selector := makePinnedSelector(sess, coll.writeSelector)
 
 	op := operation.NewInsert(docs...).
-		Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).
+		Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).Interceptors(coll.client.interceptors).
 		ServerSelector(selector).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Ordered(true).
@@ -442,11 +442,36 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 
 	selector := makePinnedSelector(sess, coll.writeSelector)
 
+	op, err := coll.newDeleteOperation(f, deleteOne, options.MergeDeleteOptions(opts...))
+	if err != nil {
+		return nil, err
+	}
+	op = op.Session(sess).WriteConcern(wc).ServerSelector(selector)
+
+	// deleteMany cannot be retried
+	retryMode := driver.RetryNone
+	if deleteOne && coll.client.retryWrites {
+		retryMode = driver.RetryOncePerCommand
+	}
+	op = op.Retry(retryMode)
+	rr, err := processWriteError(op.Execute(ctx))
+	if rr&expectedRr == 0 {
+		return nil, err
+	}
+	return &DeleteResult{DeletedCount: op.Result().N}, err
+}
+
+// newDeleteOperation creates a Delete operation with a single delete statement for the filter and options. The
+// session, write concern and server selector are not set on the returned operation.
+func (coll *Collection) newDeleteOperation(
+	f bsoncore.Document,
+	deleteOne bool,
+	do *options.DeleteOptions,
+) (*operation.Delete, error) {
 	var limit int32
 	if deleteOne {
 		limit = 1
 	}
-	do := options.MergeDeleteOptions(opts...)
 	didx, doc := bsoncore.AppendDocumentStart(nil)
 	doc = bsoncore.AppendDocumentElement(doc, "q", f)
 	doc = bsoncore.AppendInt32Element(doc, "limit", limit)
@@ -467,8 +492,7 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 	doc, _ = bsoncore.AppendDocumentEnd(doc, didx)
 
 	op := operation.NewDelete(doc).
-		Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).
-		ServerSelector(selector).ClusterClock(coll.client.clock).
+		CommandMonitor(coll.client.monitor).Interceptors(coll.client.interceptors).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Ordered(true).
 		ServerAPI(coll.client.serverAPI).Timeout(coll.client.timeout).Logger(coll.client.logger)
@@ -490,17 +514,7 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 		op = op.Let(let)
 	}
 
-	// deleteMany cannot be retried
-	retryMode := driver.RetryNone
-	if deleteOne && coll.client.retryWrites {
-		retryMode = driver.RetryOncePerCommand
-	}
-	op = op.Retry(retryMode)
-	rr, err := processWriteError(op.Execute(ctx))
-	if rr&expectedRr == 0 {
-		return nil, err
-	}
-	return &DeleteResult{DeletedCount: op.Result().N}, err
+	return op, nil
 }
 
 // DeleteOne executes a delete command to delete at most one document from the collection.
@@ -542,21 +556,7 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 		ctx = context.Background()
 	}
 
-	uo := options.MergeUpdateOptions(opts...)
-
-	// collation, arrayFilters, upsert, and hint are included on the individual update documents rather than as part of the
-	// command
-	updateDoc, err := createUpdateDoc(
-		filter,
-		update,
-		uo.Hint,
-		uo.ArrayFilters,
-		uo.Collation,
-		uo.Upsert,
-		multi,
-		checkDollarKey,
-		coll.bsonOpts,
-		coll.registry)
+	op, err := coll.newUpdateOperation(filter, update, multi, checkDollarKey, options.MergeUpdateOptions(opts...))
 	if err != nil {
 		return nil, err
 	}
@@ -581,32 +581,8 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 	}
 
 	selector := makePinnedSelector(sess, coll.writeSelector)
+	op = op.Session(sess).WriteConcern(wc).ServerSelector(selector)
 
-	op := operation.NewUpdate(updateDoc).
-		Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).
-		ServerSelector(selector).ClusterClock(coll.client.clock).
-		Database(coll.db.name).Collection(coll.name).
-		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Hint(uo.Hint != nil).
-		ArrayFilters(uo.ArrayFilters != nil).Ordered(true).ServerAPI(coll.client.serverAPI).
-		Timeout(coll.client.timeout).Logger(coll.client.logger)
-	if uo.Let != nil {
-		let, err := marshal(uo.Let, coll.bsonOpts, coll.registry)
-		if err != nil {
-			return nil, err
-		}
-		op = op.Let(let)
-	}
-
-	if uo.BypassDocumentValidation != nil && *uo.BypassDocumentValidation {
-		op = op.BypassDocumentValidation(*uo.BypassDocumentValidation)
-	}
-	if uo.Comment != nil {
-		comment, err := marshalValue(uo.Comment, coll.bsonOpts, coll.registry)
-		if err != nil {
-			return nil, err
-		}
-		op = op.Comment(comment)
-	}
 	retry := driver.RetryNone
 	// retryable writes are only enabled updateOne/replaceOne operations
 	if !multi && coll.client.retryWrites {
@@ -634,6 +610,59 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 	return res, err
 }
 
+// newUpdateOperation creates an Update operation with a single update statement for the filter, update and options.
+// The session, write concern and server selector are not set on the returned operation.
+func (coll *Collection) newUpdateOperation(
+	filter bsoncore.Document,
+	update interface{},
+	multi bool,
+	checkDollarKey bool,
+	uo *options.UpdateOptions,
+) (*operation.Update, error) {
+	// collation, arrayFilters, upsert, and hint are included on the individual update documents rather than as part of the
+	// command
+	updateDoc, err := createUpdateDoc(
+		filter,
+		update,
+		uo.Hint,
+		uo.ArrayFilters,
+		uo.Collation,
+		uo.Upsert,
+		multi,
+		checkDollarKey,
+		coll.bsonOpts,
+		coll.registry)
+	if err != nil {
+		return nil, err
+	}
+
+	op := operation.NewUpdate(updateDoc).
+		CommandMonitor(coll.client.monitor).Interceptors(coll.client.interceptors).ClusterClock(coll.client.clock).
+		Database(coll.db.name).Collection(coll.name).
+		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Hint(uo.Hint != nil).
+		ArrayFilters(uo.ArrayFilters != nil).Ordered(true).ServerAPI(coll.client.serverAPI).
+		Timeout(coll.client.timeout).Logger(coll.client.logger)
+	if uo.Let != nil {
+		let, err := marshal(uo.Let, coll.bsonOpts, coll.registry)
+		if err != nil {
+			return nil, err
+		}
+		op = op.Let(let)
+	}
+
+	if uo.BypassDocumentValidation != nil && *uo.BypassDocumentValidation {
+		op = op.BypassDocumentValidation(*uo.BypassDocumentValidation)
+	}
+	if uo.Comment != nil {
+		comment, err := marshalValue(uo.Comment, coll.bsonOpts, coll.registry)
+		if err != nil {
+			return nil, err
+		}
+		op = op.Comment(comment)
+	}
+	return op, nil
+}
+
 // UpdateByID executes an update command to update the document whose _id value matches the provided ID in the collection.
 // This is equivalent to running UpdateOne(ctx, bson.D{{"_id", id}}, update, opts...).
 //
@@ -840,19 +869,59 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 		selector = makeOutputAggregateSelector(sess, a.readPreference, a.client.localThreshold)
 	}
 
+	op, cursorOpts, err := newAggregateOperation(a, pipelineArr, hasOutputStage)
+	if err != nil {
+		return nil, err
+	}
+	op = op.Session(sess).WriteConcern(wc).ReadConcern(rc).ServerSelector(selector)
+
+	// Omit "maxTimeMS" from operations that return a user-managed cursor to
+	// prevent confusing "cursor not found" errors. To maintain existing
+	// behavior for users who set "timeoutMS" with no context deadline, only
+	// omit "maxTimeMS" when a context deadline is set.
+	//
+	// See DRIVERS-2722 for more detail.
+	_, deadlineSet := a.ctx.Deadline()
+	op.OmitCSOTMaxTimeMS(deadlineSet)
+
+	retry := driver.RetryNone
+	if a.retryRead && !hasOutputStage {
+		retry = driver.RetryOncePerCommand
+	}
+	op = op.Retry(retry)
+
+	err = op.Execute(a.ctx)
+	if err != nil {
+		if wce, ok := err.(driver.WriteCommandError); ok && wce.WriteConcernError != nil {
+			return nil, *convertDriverWriteConcernError(wce.WriteConcernError)
+		}
+		return nil, replaceErrors(err)
+	}
+
+	bc, err := op.Result(cursorOpts)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	cursor, err := newCursorWithSession(bc, a.client.bsonOpts, a.registry, sess)
+	return cursor, replaceErrors(err)
+}
+
+// newAggregateOperation creates an Aggregate operation for the marshalled pipeline and the options in a. The session,
+// read and write concerns and server selector are not set on the returned operation. The returned CursorOptions should
+// be used to create a cursor from the result.
+func newAggregateOperation(
+	a aggregateParams,
+	pipelineArr bsoncore.Document,
+	hasOutputStage bool,
+) (*operation.Aggregate, driver.CursorOptions, error) {
 	ao := options.MergeAggregateOptions(a.opts...)
 
 	cursorOpts := a.client.createBaseCursorOptions()
-
 	cursorOpts.MarshalValueEncoderFn = newEncoderFn(a.bsonOpts, a.registry)
 
 	op := operation.NewAggregate(pipelineArr).
-		Session(sess).
-		WriteConcern(wc).
-		ReadConcern(rc).
 		ReadPreference(a.readPreference).
-		CommandMonitor(a.client.monitor).
-		ServerSelector(selector).
+		CommandMonitor(a.client.monitor).Interceptors(a.client.interceptors).
 		ClusterClock(a.client.clock).
 		Database(a.db).
 		Collection(a.col).
@@ -863,15 +932,6 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 		Timeout(a.client.timeout).
 		MaxTime(ao.MaxTime)
 
-	// Omit "maxTimeMS" from operations that return a user-managed cursor to
-	// prevent confusing "cursor not found" errors. To maintain existing
-	// behavior for users who set "timeoutMS" with no context deadline, only
-	// omit "maxTimeMS" when a context deadline is set.
-	//
-	// See DRIVERS-2722 for more detail.
-	_, deadlineSet := a.ctx.Deadline()
-	op.OmitCSOTMaxTimeMS(deadlineSet)
-
 	if ao.AllowDiskUse != nil {
 		op.AllowDiskUse(*ao.AllowDiskUse)
 	}
@@ -880,6 +940,9 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 		op.BatchSize(*ao.BatchSize)
 		cursorOpts.BatchSize = *ao.BatchSize
 	}
+	if ao.PrefetchBatches != nil {
+		cursorOpts.PrefetchBatches = *ao.PrefetchBatches
+	}
 	if ao.BypassDocumentValidation != nil && *ao.BypassDocumentValidation {
 		op.BypassDocumentValidation(*ao.BypassDocumentValidation)
 	}
@@ -894,24 +957,24 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 
 		commentVal, err := marshalValue(ao.Comment, a.bsonOpts, a.registry)
 		if err != nil {
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		cursorOpts.Comment = commentVal
 	}
 	if ao.Hint != nil {
 		if isUnorderedMap(ao.Hint) {
-			return nil, ErrMapForOrderedArgument{"hint"}
+			return nil, cursorOpts, ErrMapForOrderedArgument{"hint"}
 		}
 		hintVal, err := marshalValue(ao.Hint, a.bsonOpts, a.registry)
 		if err != nil {
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Hint(hintVal)
 	}
 	if ao.Let != nil {
 		let, err := marshal(ao.Let, a.bsonOpts, a.registry)
 		if err != nil {
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Let(let)
 	}
@@ -922,7 +985,7 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 		for optionName, optionValue := range ao.Custom {
 			bsonType, bsonData, err := bson.MarshalValueWithRegistry(a.registry, optionValue)
 			if err != nil {
-				return nil, err
+				return nil, cursorOpts, err
 			}
 			optionValueBSON := bsoncore.Value{Type: bsonType, Data: bsonData}
 			customOptions[optionName] = optionValueBSON
@@ -930,26 +993,7 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 		op.CustomOptions(customOptions)
 	}
 
-	retry := driver.RetryNone
-	if a.retryRead && !hasOutputStage {
-		retry = driver.RetryOncePerCommand
-	}
-	op = op.Retry(retry)
-
-	err = op.Execute(a.ctx)
-	if err != nil {
-		if wce, ok := err.(driver.WriteCommandError); ok && wce.WriteConcernError != nil {
-			return nil, *convertDriverWriteConcernError(wce.WriteConcernError)
-		}
-		return nil, replaceErrors(err)
-	}
-
-	bc, err := op.Result(cursorOpts)
-	if err != nil {
-		return nil, replaceErrors(err)
-	}
-	cursor, err := newCursorWithSession(bc, a.client.bsonOpts, a.registry, sess)
-	return cursor, replaceErrors(err)
+	return op, cursorOpts, nil
 }
 
 // CountDocuments returns the number of documents in the collection. For a fast count of the documents in the
@@ -967,9 +1011,7 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 		ctx = context.Background()
 	}
 
-	countOpts := options.MergeCountOptions(opts...)
-
-	pipelineArr, err := countDocumentsAggregatePipeline(filter, coll.bsonOpts, coll.registry, countOpts)
+	op, err := coll.newCountDocumentsOperation(filter, options.MergeCountOptions(opts...))
 	if err != nil {
 		return 0, err
 	}
@@ -989,26 +1031,8 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	}
 
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
-	op := operation.NewAggregate(pipelineArr).Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
-		CommandMonitor(coll.client.monitor).ServerSelector(selector).ClusterClock(coll.client.clock).Database(coll.db.name).
-		Collection(coll.name).Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
-		Timeout(coll.client.timeout).MaxTime(countOpts.MaxTime)
-	if countOpts.Collation != nil {
-		op.Collation(bsoncore.Document(countOpts.Collation.ToDocument()))
-	}
-	if countOpts.Comment != nil {
-		op.Comment(*countOpts.Comment)
-	}
-	if countOpts.Hint != nil {
-		if isUnorderedMap(countOpts.Hint) {
-			return 0, ErrMapForOrderedArgument{"hint"}
-		}
-		hintVal, err := marshalValue(countOpts.Hint, coll.bsonOpts, coll.registry)
-		if err != nil {
-			return 0, err
-		}
-		op.Hint(hintVal)
-	}
+	op = op.Session(sess).ReadConcern(rc).ServerSelector(selector)
+
 	retry := driver.RetryNone
 	if coll.client.retryReads {
 		retry = driver.RetryOncePerCommand
@@ -1038,6 +1062,41 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	return val, nil
 }
 
+// newCountDocumentsOperation creates the Aggregate operation used by CountDocuments for the filter and options. The
+// session, read concern and server selector are not set on the returned operation.
+func (coll *Collection) newCountDocumentsOperation(
+	filter interface{},
+	countOpts *options.CountOptions,
+) (*operation.Aggregate, error) {
+	pipelineArr, err := countDocumentsAggregatePipeline(filter, coll.bsonOpts, coll.registry, countOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	op := operation.NewAggregate(pipelineArr).ReadPreference(coll.readPreference).
+		CommandMonitor(coll.client.monitor).
+		Interceptors(coll.client.interceptors).ClusterClock(coll.client.clock).Database(coll.db.name).
+		Collection(coll.name).Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
+		Timeout(coll.client.timeout).MaxTime(countOpts.MaxTime)
+	if countOpts.Collation != nil {
+		op.Collation(bsoncore.Document(countOpts.Collation.ToDocument()))
+	}
+	if countOpts.Comment != nil {
+		op.Comment(*countOpts.Comment)
+	}
+	if countOpts.Hint != nil {
+		if isUnorderedMap(countOpts.Hint) {
+			return nil, ErrMapForOrderedArgument{"hint"}
+		}
+		hintVal, err := marshalValue(countOpts.Hint, coll.bsonOpts, coll.registry)
+		if err != nil {
+			return nil, err
+		}
+		op.Hint(hintVal)
+	}
+	return op, nil
+}
+
 // EstimatedDocumentCount executes a count command and returns an estimate of the number of documents in the collection
 // using collection metadata.
 //
@@ -1075,6 +1134,7 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
 	op := operation.NewCount().Session(sess).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
+		Interceptors(coll.client.interceptors).
 		Deployment(coll.client.deployment).ReadConcern(rc).ReadPreference(coll.readPreference).
 		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
 		Timeout(coll.client.timeout).MaxTime(co.MaxTime)
@@ -1137,25 +1197,12 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 	}
 
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
-	option := options.MergeDistinctOptions(opts...)
-
-	op := operation.NewDistinct(fieldName, f).
-		Session(sess).ClusterClock(coll.client.clock).
-		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
-		Deployment(coll.client.deployment).ReadConcern(rc).ReadPreference(coll.readPreference).
-		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
-		Timeout(coll.client.timeout).MaxTime(option.MaxTime)
-
-	if option.Collation != nil {
-		op.Collation(bsoncore.Document(option.Collation.ToDocument()))
-	}
-	if option.Comment != nil {
-		comment, err := marshalValue(option.Comment, coll.bsonOpts, coll.registry)
-		if err != nil {
-			return nil, err
-		}
-		op.Comment(comment)
+	op, err := coll.newDistinctOperation(fieldName, f, options.MergeDistinctOptions(opts...))
+	if err != nil {
+		return nil, err
 	}
+	op = op.Session(sess).ReadConcern(rc).ServerSelector(selector)
+
 	retry := driver.RetryNone
 	if coll.client.retryReads {
 		retry = driver.RetryOncePerCommand
@@ -1190,6 +1237,34 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 	return retArray, replaceErrors(err)
 }
 
+// newDistinctOperation creates a Distinct operation for the field name, filter and options. The session, read concern
+// and server selector are not set on the returned operation.
+func (coll *Collection) newDistinctOperation(
+	fieldName string,
+	f bsoncore.Document,
+	option *options.DistinctOptions,
+) (*operation.Distinct, error) {
+	op := operation.NewDistinct(fieldName, f).
+		ClusterClock(coll.client.clock).
+		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
+		Interceptors(coll.client.interceptors).
+		Deployment(coll.client.deployment).ReadPreference(coll.readPreference).
+		Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
+		Timeout(coll.client.timeout).MaxTime(option.MaxTime)
+
+	if option.Collation != nil {
+		op.Collation(bsoncore.Document(option.Collation.ToDocument()))
+	}
+	if option.Comment != nil {
+		comment, err := marshalValue(option.Comment, coll.bsonOpts, coll.registry)
+		if err != nil {
+			return nil, err
+		}
+		op.Comment(comment)
+	}
+	return op, nil
+}
+
 // Find executes a find command and returns a Cursor over the matching documents in the collection.
 //
 // The filter parameter must be a document containing query operators and can be used to select which documents are
@@ -1248,16 +1323,41 @@ func (coll *Collection) find(
 		rc = nil
 	}
 
-	fo := options.MergeFindOptions(opts...)
-
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
+	op, cursorOpts, err := coll.newFindOperation(f, options.MergeFindOptions(opts...))
+	if err != nil {
+		return nil, err
+	}
+	op = op.Session(sess).ReadConcern(rc).ServerSelector(selector).OmitCSOTMaxTimeMS(omitCSOTMaxTimeMS)
+
+	retry := driver.RetryNone
+	if coll.client.retryReads {
+		retry = driver.RetryOncePerCommand
+	}
+	op = op.Retry(retry)
+
+	if err = op.Execute(ctx); err != nil {
+		return nil, replaceErrors(err)
+	}
+
+	bc, err := op.Result(cursorOpts)
+	if err != nil {
+		return nil, replaceErrors(err)
+	}
+	return newCursorWithSession(bc, coll.bsonOpts, coll.registry, sess)
+}
+
+// newFindOperation creates a Find operation for the filter and options. The session, read concern and server selector
+// are not set on the returned operation. The returned CursorOptions should be used to create a cursor from the result.
+func (coll *Collection) newFindOperation(
+	f bsoncore.Document,
+	fo *options.FindOptions,
+) (*operation.Find, driver.CursorOptions, error) {
 	op := operation.NewFind(f).
-		Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
-		CommandMonitor(coll.client.monitor).ServerSelector(selector).
+		ReadPreference(coll.readPreference).CommandMonitor(coll.client.monitor).Interceptors(coll.client.interceptors).
 		ClusterClock(coll.client.clock).Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
-		Timeout(coll.client.timeout).MaxTime(fo.MaxTime).Logger(coll.client.logger).
-		OmitCSOTMaxTimeMS(omitCSOTMaxTimeMS)
+		Timeout(coll.client.timeout).MaxTime(fo.MaxTime).Logger(coll.client.logger)
 
 	cursorOpts := coll.client.createBaseCursorOptions()
 
@@ -1273,6 +1373,9 @@ func (coll *Collection) find(
 		cursorOpts.BatchSize = *fo.BatchSize
 		op.BatchSize(*fo.BatchSize)
 	}
+	if fo.PrefetchBatches != nil {
+		cursorOpts.PrefetchBatches = *fo.PrefetchBatches
+	}
 	if fo.Collation != nil {
 		op.Collation(bsoncore.Document(fo.Collation.ToDocument()))
 	}
@@ -1281,7 +1384,7 @@ func (coll *Collection) find(
 
 		commentVal, err := marshalValue(fo.Comment, coll.bsonOpts, coll.registry)
 		if err != nil {
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		cursorOpts.Comment = commentVal
 	}
@@ -1296,18 +1399,18 @@ func (coll *Collection) find(
 	}
 	if fo.Hint != nil {
 		if isUnorderedMap(fo.Hint) {
-			return nil, ErrMapForOrderedArgument{"hint"}
+			return nil, cursorOpts, ErrMapForOrderedArgument{"hint"}
 		}
 		hint, err := marshalValue(fo.Hint, coll.bsonOpts, coll.registry)
 		if err != nil {
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Hint(hint)
 	}
 	if fo.Let != nil {
 		let, err := marshal(fo.Let, coll.bsonOpts, coll.registry)
 		if err != nil {
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Let(let)
 	}
@@ -1323,7 +1426,7 @@ func (coll *Collection) find(
 	if fo.Max != nil {
 		max, err := marshal(fo.Max, coll.bsonOpts, coll.registry)
 		if err != nil {
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Max(max)
 	}
@@ -1333,7 +1436,7 @@ func (coll *Collection) find(
 	if fo.Min != nil {
 		min, err := marshal(fo.Min, coll.bsonOpts, coll.registry)
 		if err != nil {
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Min(min)
 	}
@@ -1346,7 +1449,7 @@ func (coll *Collection) find(
 	if fo.Projection != nil {
 		proj, err := marshal(fo.Projection, coll.bsonOpts, coll.registry)
 		if err != nil {
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Projection(proj)
 	}
@@ -1364,29 +1467,16 @@ func (coll *Collection) find(
 	}
 	if fo.Sort != nil {
 		if isUnorderedMap(fo.Sort) {
-			return nil, ErrMapForOrderedArgument{"sort"}
+			return nil, cursorOpts, ErrMapForOrderedArgument{"sort"}
 		}
 		sort, err := marshal(fo.Sort, coll.bsonOpts, coll.registry)
 		if err != nil {
-			return nil, err
+			return nil, cursorOpts, err
 		}
 		op.Sort(sort)
 	}
-	retry := driver.RetryNone
-	if coll.client.retryReads {
-		retry = driver.RetryOncePerCommand
-	}
-	op = op.Retry(retry)
-
-	if err = op.Execute(ctx); err != nil {
-		return nil, replaceErrors(err)
-	}
 
-	bc, err := op.Result(cursorOpts)
-	if err != nil {
-		return nil, replaceErrors(err)
-	}
-	return newCursorWithSession(bc, coll.bsonOpts, coll.registry, sess)
+	return op, cursorOpts, nil
 }
 
 // FindOne executes a find command and returns a SingleResult for one document in the collection.
@@ -1479,7 +1569,7 @@ func (coll *Collection) findAndModify(ctx context.Context, op *operation.FindAnd
 
 	op = op.Session(sess).
 		WriteConcern(wc).
-		CommandMonitor(coll.client.monitor).
+		CommandMonitor(coll.client.monitor).Interceptors(coll.client.interceptors).
 		ServerSelector(selector).
 		ClusterClock(coll.client.clock).
 		Database(coll.db.name).
@@ -1890,7 +1980,7 @@ func (coll *Collection) drop(ctx context.Context) error {
 	selector := makePinnedSelector(sess, coll.writeSelector)
 
 	op := operation.NewDropCollection().
-		Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).
+		Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).Interceptors(coll.client.interceptors).
 		ServerSelector(selector).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).
@@ -1905,6 +1995,136 @@ func (coll *Collection) drop(ctx context.Context) error {
 	return nil
 }
 
+// Rename executes a renameCollection command to rename the collection to the given name within the same database.
+// The Collection is not modified; use Database.Collection to obtain a handle for the renamed collection.
+//
+// See Database.RenameCollection for the errors returned when the target exists or the collection does not exist.
+//
+// The opts parameter can be used to specify options for the operation (see the options.RenameCollectionOptions
+// documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/renameCollection/.
+func (coll *Collection) Rename(ctx context.Context, to string, opts ...*options.RenameCollectionOptions) error {
+	return coll.db.renameCollection(ctx, coll.name, to, coll.writeConcern, opts...)
+}
+
+// Modify executes a collMod command to change the options of the collection. Only the options that are set in opts
+// are changed. The opts parameter cannot be nil.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/collMod/.
+func (coll *Collection) Modify(ctx context.Context, opts *options.ModifyCollectionOptions) error {
+	if opts == nil {
+		return errors.New("modify collection options must not be nil")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sess := sessionFromContext(ctx)
+	if sess == nil && coll.client.sessionPool != nil {
+		sess = session.NewImplicitClientSession(coll.client.sessionPool, coll.client.id)
+		defer sess.EndSession()
+	}
+
+	err := coll.client.validSession(sess)
+	if err != nil {
+		return err
+	}
+
+	wc := coll.writeConcern
+	if sess.TransactionRunning() {
+		wc = nil
+	}
+	if !writeconcern.AckWrite(wc) {
+		sess = nil
+	}
+
+	selector := makePinnedSelector(sess, coll.writeSelector)
+
+	op := operation.NewCollMod(coll.name).
+		Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).Interceptors(coll.client.interceptors).
+		ServerSelector(selector).ClusterClock(coll.client.clock).
+		Database(coll.db.name).Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).
+		ServerAPI(coll.client.serverAPI).Timeout(coll.client.timeout).Logger(coll.client.logger)
+
+	mco := options.MergeModifyCollectionOptions(opts)
+	if mco.Validator != nil {
+		validator, err := marshal(mco.Validator, coll.bsonOpts, coll.registry)
+		if err != nil {
+			return err
+		}
+		op.Validator(validator)
+	}
+	if mco.ValidationLevel != nil {
+		op.ValidationLevel(*mco.ValidationLevel)
+	}
+	if mco.ValidationAction != nil {
+		op.ValidationAction(*mco.ValidationAction)
+	}
+	if mco.ChangeStreamPreAndPostImages != nil {
+		csppi, err := marshal(mco.ChangeStreamPreAndPostImages, coll.bsonOpts, coll.registry)
+		if err != nil {
+			return err
+		}
+		op.ChangeStreamPreAndPostImages(csppi)
+	}
+	if mco.Index != nil {
+		index, err := coll.marshalModifyIndex(mco.Index)
+		if err != nil {
+			return err
+		}
+		op.Index(index)
+	}
+	if mco.ExpireAfterSeconds != nil {
+		op.ExpireAfterSeconds(*mco.ExpireAfterSeconds)
+	}
+	if (mco.ViewOn == nil) != (mco.Pipeline == nil) {
+		return errors.New("the ViewOn and Pipeline options must be set together to modify a view")
+	}
+	if mco.ViewOn != nil {
+		pipeline, _, err := marshalAggregatePipeline(mco.Pipeline, coll.bsonOpts, coll.registry)
+		if err != nil {
+			return err
+		}
+		op.ViewOn(*mco.ViewOn).Pipeline(pipeline)
+	}
+
+	return replaceErrors(op.Execute(ctx))
+}
+
+// marshalModifyIndex builds the "index" document of a collMod command from the given options.
+func (coll *Collection) marshalModifyIndex(mio *options.ModifyIndexOptions) (bsoncore.Document, error) {
+	if (mio.Name == nil) == (mio.Keys == nil) {
+		return nil, errors.New("exactly one of the Name and Keys index options must be set")
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	if mio.Name != nil {
+		doc = bsoncore.AppendStringElement(doc, "name", *mio.Name)
+	}
+	if mio.Keys != nil {
+		keys, err := marshal(mio.Keys, coll.bsonOpts, coll.registry)
+		if err != nil {
+			return nil, err
+		}
+		doc = bsoncore.AppendDocumentElement(doc, "keyPattern", keys)
+	}
+	if mio.Hidden != nil {
+		doc = bsoncore.AppendBooleanElement(doc, "hidden", *mio.Hidden)
+	}
+	if mio.ExpireAfterSeconds != nil {
+		doc = bsoncore.AppendInt64Element(doc, "expireAfterSeconds", *mio.ExpireAfterSeconds)
+	}
+	if mio.PrepareUnique != nil {
+		doc = bsoncore.AppendBooleanElement(doc, "prepareUnique", *mio.PrepareUnique)
+	}
+	if mio.Unique != nil {
+		doc = bsoncore.AppendBooleanElement(doc, "unique", *mio.Unique)
+	}
+	return bsoncore.AppendDocumentEnd(doc, idx)
+}
+
 type pinnedServerSelector struct {
 	stringer fmt.Stringer
 	fallback description.ServerSelector
@@ -109,6 +109,11 @@ func (db *Database) Collection(name string, opts ...*options.CollectionOptions)
 	return newCollection(db, name, opts...)
 }
 
+// Users returns a UserView for this database.
+func (db *Database) Users() UserView {
+	return UserView{db: db}
+}
+
 // Aggregate executes an aggregate command the database. This requires MongoDB version >= 3.6 and driver version >=
 // 1.1.0.
 //
@@ -185,7 +190,7 @@ func (db *Database) processRunCommand(ctx context.Context, cmd interface{},
 		op = operation.NewCommand(runCmdDoc)
 	}
 
-	return op.Session(sess).CommandMonitor(db.client.monitor).
+	return op.Session(sess).CommandMonitor(db.client.monitor).Interceptors(db.client.interceptors).
 		ServerSelector(readSelect).ClusterClock(db.client.clock).
 		Database(db.name).Deployment(db.client.deployment).
 		Crypt(db.client.cryptFLE).ReadPreference(ro.ReadPreference).ServerAPI(db.client.serverAPI).
@@ -305,7 +310,7 @@ func (db *Database) Drop(ctx context.Context) error {
 	selector := makePinnedSelector(sess, db.writeSelector)
 
 	op := operation.NewDropDatabase().
-		Session(sess).WriteConcern(wc).CommandMonitor(db.client.monitor).
+		Session(sess).WriteConcern(wc).CommandMonitor(db.client.monitor).Interceptors(db.client.interceptors).
 		ServerSelector(selector).ClusterClock(db.client.clock).
 		Database(db.name).Deployment(db.client.deployment).Crypt(db.client.cryptFLE).
 		ServerAPI(db.client.serverAPI)
@@ -319,6 +324,81 @@ func (db *Database) Drop(ctx context.Context) error {
 	return nil
 }
 
+// RenameCollection executes a renameCollection command to rename the collection named from to the name to. Both
+// collections are in this database.
+//
+// If the target collection exists and the DropTarget option is not set, the returned error will be a CommandError
+// wrapping ErrNamespaceExists. If the source collection does not exist, the returned error will be a CommandError
+// wrapping ErrSourceNamespaceNotFound. Both can be checked with errors.Is.
+//
+// The opts parameter can be used to specify options for the operation (see the options.RenameCollectionOptions
+// documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/renameCollection/.
+func (db *Database) RenameCollection(ctx context.Context, from, to string,
+	opts ...*options.RenameCollectionOptions) error {
+
+	return db.renameCollection(ctx, from, to, db.writeConcern, opts...)
+}
+
+func (db *Database) renameCollection(ctx context.Context, from, to string, wc *writeconcern.WriteConcern,
+	opts ...*options.RenameCollectionOptions) error {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sess := sessionFromContext(ctx)
+	if sess == nil && db.client.sessionPool != nil {
+		sess = session.NewImplicitClientSession(db.client.sessionPool, db.client.id)
+		defer sess.EndSession()
+	}
+
+	err := db.client.validSession(sess)
+	if err != nil {
+		return err
+	}
+
+	if sess.TransactionRunning() {
+		wc = nil
+	}
+	if !writeconcern.AckWrite(wc) {
+		sess = nil
+	}
+
+	selector := makePinnedSelector(sess, db.writeSelector)
+
+	rco := options.MergeRenameCollectionOptions(opts...)
+	op := operation.NewRenameCollection(db.name+"."+from, db.name+"."+to).
+		Session(sess).WriteConcern(wc).CommandMonitor(db.client.monitor).Interceptors(db.client.interceptors).
+		ServerSelector(selector).ClusterClock(db.client.clock).
+		Deployment(db.client.deployment).Crypt(db.client.cryptFLE).
+		ServerAPI(db.client.serverAPI).Timeout(db.client.timeout).Logger(db.client.logger)
+	if rco.DropTarget != nil {
+		op = op.DropTarget(*rco.DropTarget)
+	}
+	if rco.Comment != nil {
+		comment, err := marshalValue(rco.Comment, db.bsonOpts, db.registry)
+		if err != nil {
+			return err
+		}
+		op = op.Comment(comment)
+	}
+
+	err = replaceErrors(op.Execute(ctx))
+	if ce, ok := err.(CommandError); ok {
+		switch ce.Code {
+		case 48: // NamespaceExists
+			ce.Wrapped = ErrNamespaceExists
+			return ce
+		case 26: // NamespaceNotFound
+			ce.Wrapped = ErrSourceNamespaceNotFound
+			return ce
+		}
+	}
+	return err
+}
+
 // ListCollectionSpecifications executes a listCollections command and returns a slice of CollectionSpecification
 // instances representing the collections in the database.
 //
@@ -400,6 +480,7 @@ func (db *Database) ListCollections(ctx context.Context, filter interface{}, opt
 	lco := options.MergeListCollectionsOptions(opts...)
 	op := operation.NewListCollections(filterDoc).
 		Session(sess).ReadPreference(db.readPreference).CommandMonitor(db.client.monitor).
+		Interceptors(db.client.interceptors).
 		ServerSelector(selector).ClusterClock(db.client.clock).
 		Database(db.name).Deployment(db.client.deployment).Crypt(db.client.cryptFLE).
 		ServerAPI(db.client.serverAPI).Timeout(db.client.timeout)
@@ -837,7 +918,7 @@ func (db *Database) executeCreateOperation(ctx context.Context, op *operation.Cr
 	selector := makePinnedSelector(sess, db.writeSelector)
 	op = op.Session(sess).
 		WriteConcern(wc).
-		CommandMonitor(db.client.monitor).
+		CommandMonitor(db.client.monitor).Interceptors(db.client.interceptors).
 		ServerSelector(selector).
 		ClusterClock(db.client.clock).
 		Database(db.name).
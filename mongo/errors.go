@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 
 	"github.com/zhangdapeng520/zdpgo_mongo/bson"
@@ -36,6 +37,13 @@ var ErrNilValue = errors.New("value is nil")
 // ErrEmptySlice is returned when an empty slice is passed to a CRUD method that requires a non-empty slice.
 var ErrEmptySlice = errors.New("must provide at least one element in input slice")
 
+// ErrNamespaceExists is wrapped by the CommandError returned when the target of a rename already exists and the
+// DropTarget option was not set.
+var ErrNamespaceExists = errors.New("target namespace exists")
+
+// ErrSourceNamespaceNotFound is wrapped by the CommandError returned when the source of a rename does not exist.
+var ErrSourceNamespaceNotFound = errors.New("source namespace does not exist")
+
 // ErrMapForOrderedArgument is returned when a map with multiple keys is passed to a CRUD method for an ordered parameter
 type ErrMapForOrderedArgument struct {
 	ParamName string
@@ -609,6 +617,61 @@ func (bwe BulkWriteException) HasErrorCodeWithMessage(code int, message string)
 // serverError implements the ServerError interface.
 func (bwe BulkWriteException) serverError() {}
 
+// ClientBulkWriteException is the error type returned by a Client.BulkWrite operation when some of the writes could
+// not be completed.
+type ClientBulkWriteException struct {
+	// The top-level error that interrupted the operation, or nil if there was none. Writes that were not sent to the
+	// server before the error occurred are not reported in WriteErrors.
+	TopLevelError error
+
+	// The write concern errors that occurred, one for each batch that reported one.
+	WriteConcernErrors []WriteConcernError
+
+	// A map of model index to the write error that occurred for that write.
+	WriteErrors map[int]WriteError
+
+	// The result of the writes that completed before the operation failed, or nil if no writes completed.
+	PartialResult *ClientBulkWriteResult
+}
+
+// Error implements the error interface.
+func (cbwe ClientBulkWriteException) Error() string {
+	causes := make([]string, 0, 3)
+	if cbwe.TopLevelError != nil {
+		causes = append(causes, "top level error: "+cbwe.TopLevelError.Error())
+	}
+	if len(cbwe.WriteConcernErrors) > 0 {
+		errs := make([]error, len(cbwe.WriteConcernErrors))
+		for i := 0; i < len(cbwe.WriteConcernErrors); i++ {
+			errs[i] = cbwe.WriteConcernErrors[i]
+		}
+		causes = append(causes, "write concern errors: "+joinBatchErrors(errs))
+	}
+	if len(cbwe.WriteErrors) > 0 {
+		indexes := make([]int, 0, len(cbwe.WriteErrors))
+		for idx := range cbwe.WriteErrors {
+			indexes = append(indexes, idx)
+		}
+		sort.Ints(indexes)
+		errs := make([]error, len(indexes))
+		for i, idx := range indexes {
+			errs[i] = cbwe.WriteErrors[idx]
+		}
+		causes = append(causes, "write errors: "+joinBatchErrors(errs))
+	}
+
+	message := "client bulk write exception: "
+	if len(causes) == 0 {
+		return message + "no causes"
+	}
+	return message + strings.Join(causes, ", ")
+}
+
+// Unwrap returns the top-level error, if one exists.
+func (cbwe ClientBulkWriteException) Unwrap() error {
+	return cbwe.TopLevelError
+}
+
 // returnResult is used to determine if a function calling processWriteError should return
 // the result or return nil. Since the processWriteError function is used by many different
 // methods, both *One and *Many, we need a way to differentiate if the method should return
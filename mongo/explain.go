@@ -0,0 +1,283 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/description"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/readpref"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver/operation"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver/session"
+)
+
+// ExplainResult is the result type returned by the Collection.Explain* methods. Fields that are not returned by the
+// server for the requested verbosity are left as their zero values.
+type ExplainResult struct {
+	// Information about the plan selected by the query optimizer. This is not set at the top level for aggregations
+	// with multiple stages; see Stages instead.
+	QueryPlanner *ExplainQueryPlanner `bson:"queryPlanner"`
+
+	// Execution statistics for the winning plan. This is only returned for the ExecutionStats and
+	// AllPlansExecution verbosity modes.
+	ExecutionStats *ExplainExecutionStats `bson:"executionStats"`
+
+	// The per-stage explain output for aggregations that cannot be explained as a single query.
+	Stages []bson.Raw `bson:"stages"`
+
+	// The per-shard explain output when running against a sharded cluster.
+	Shards bson.Raw `bson:"shards"`
+
+	// The command that was explained, as reported by the server.
+	Command bson.Raw `bson:"command"`
+
+	// Information about the server that produced the plan.
+	ServerInfo *ExplainServerInfo `bson:"serverInfo"`
+
+	// The raw explain output returned by the server.
+	Raw bson.Raw `bson:"-"`
+}
+
+// ExplainQueryPlanner contains the queryPlanner section of explain output.
+type ExplainQueryPlanner struct {
+	PlannerVersion int32      `bson:"plannerVersion"`
+	Namespace      string     `bson:"namespace"`
+	IndexFilterSet bool       `bson:"indexFilterSet"`
+	ParsedQuery    bson.Raw   `bson:"parsedQuery"`
+	WinningPlan    bson.Raw   `bson:"winningPlan"`
+	RejectedPlans  []bson.Raw `bson:"rejectedPlans"`
+}
+
+// ExplainExecutionStats contains the executionStats section of explain output.
+type ExplainExecutionStats struct {
+	ExecutionSuccess    bool       `bson:"executionSuccess"`
+	NReturned           int64      `bson:"nReturned"`
+	ExecutionTimeMillis int64      `bson:"executionTimeMillis"`
+	TotalKeysExamined   int64      `bson:"totalKeysExamined"`
+	TotalDocsExamined   int64      `bson:"totalDocsExamined"`
+	ExecutionStages     bson.Raw   `bson:"executionStages"`
+	AllPlansExecution   []bson.Raw `bson:"allPlansExecution"`
+}
+
+// ExplainServerInfo contains the serverInfo section of explain output.
+type ExplainServerInfo struct {
+	Host       string `bson:"host"`
+	Port       int32  `bson:"port"`
+	Version    string `bson:"version"`
+	GitVersion string `bson:"gitVersion"`
+}
+
+// ExplainFind executes an explain command for a find command with the given filter and options. The explainOpts
+// parameter can be used to set the verbosity of the explain command (see the options.ExplainOptions documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/explain/.
+func (coll *Collection) ExplainFind(ctx context.Context, filter interface{}, explainOpts *options.ExplainOptions,
+	opts ...*options.FindOptions) (*ExplainResult, error) {
+
+	f, err := marshal(filter, coll.bsonOpts, coll.registry)
+	if err != nil {
+		return nil, err
+	}
+	op, _, err := coll.newFindOperation(f, options.MergeFindOptions(opts...))
+	if err != nil {
+		return nil, err
+	}
+	return coll.explain(ctx, op, explainOpts, coll.readSelector, coll.readPreference)
+}
+
+// ExplainAggregate executes an explain command for an aggregate command with the given pipeline and options. The
+// explainOpts parameter can be used to set the verbosity of the explain command (see the options.ExplainOptions
+// documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/explain/.
+func (coll *Collection) ExplainAggregate(ctx context.Context, pipeline interface{}, explainOpts *options.ExplainOptions,
+	opts ...*options.AggregateOptions) (*ExplainResult, error) {
+
+	a := aggregateParams{
+		client:         coll.client,
+		registry:       coll.registry,
+		bsonOpts:       coll.bsonOpts,
+		db:             coll.db.name,
+		col:            coll.name,
+		readPreference: coll.readPreference,
+		opts:           opts,
+	}
+	pipelineArr, hasOutputStage, err := marshalAggregatePipeline(pipeline, coll.bsonOpts, coll.registry)
+	if err != nil {
+		return nil, err
+	}
+	op, _, err := newAggregateOperation(a, pipelineArr, hasOutputStage)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, rp := coll.readSelector, coll.readPreference
+	if hasOutputStage {
+		selector, rp = coll.writeSelector, readpref.Primary()
+	}
+	return coll.explain(ctx, op, explainOpts, selector, rp)
+}
+
+// ExplainCountDocuments executes an explain command for the aggregation used by CountDocuments with the given filter
+// and options. The explainOpts parameter can be used to set the verbosity of the explain command (see the
+// options.ExplainOptions documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/explain/.
+func (coll *Collection) ExplainCountDocuments(ctx context.Context, filter interface{},
+	explainOpts *options.ExplainOptions, opts ...*options.CountOptions) (*ExplainResult, error) {
+
+	op, err := coll.newCountDocumentsOperation(filter, options.MergeCountOptions(opts...))
+	if err != nil {
+		return nil, err
+	}
+	return coll.explain(ctx, op, explainOpts, coll.readSelector, coll.readPreference)
+}
+
+// ExplainDistinct executes an explain command for a distinct command with the given field name, filter and options.
+// The explainOpts parameter can be used to set the verbosity of the explain command (see the options.ExplainOptions
+// documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/explain/.
+func (coll *Collection) ExplainDistinct(ctx context.Context, fieldName string, filter interface{},
+	explainOpts *options.ExplainOptions, opts ...*options.DistinctOptions) (*ExplainResult, error) {
+
+	f, err := marshal(filter, coll.bsonOpts, coll.registry)
+	if err != nil {
+		return nil, err
+	}
+	op, err := coll.newDistinctOperation(fieldName, f, options.MergeDistinctOptions(opts...))
+	if err != nil {
+		return nil, err
+	}
+	return coll.explain(ctx, op, explainOpts, coll.readSelector, coll.readPreference)
+}
+
+// ExplainUpdateOne executes an explain command for an update command that would update at most one document. The
+// update is not applied. The explainOpts parameter can be used to set the verbosity of the explain command (see the
+// options.ExplainOptions documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/explain/.
+func (coll *Collection) ExplainUpdateOne(ctx context.Context, filter interface{}, update interface{},
+	explainOpts *options.ExplainOptions, opts ...*options.UpdateOptions) (*ExplainResult, error) {
+
+	return coll.explainUpdate(ctx, filter, update, false, explainOpts, opts...)
+}
+
+// ExplainUpdateMany executes an explain command for an update command that would update all matching documents. The
+// update is not applied. The explainOpts parameter can be used to set the verbosity of the explain command (see the
+// options.ExplainOptions documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/explain/.
+func (coll *Collection) ExplainUpdateMany(ctx context.Context, filter interface{}, update interface{},
+	explainOpts *options.ExplainOptions, opts ...*options.UpdateOptions) (*ExplainResult, error) {
+
+	return coll.explainUpdate(ctx, filter, update, true, explainOpts, opts...)
+}
+
+func (coll *Collection) explainUpdate(ctx context.Context, filter interface{}, update interface{}, multi bool,
+	explainOpts *options.ExplainOptions, opts ...*options.UpdateOptions) (*ExplainResult, error) {
+
+	f, err := marshal(filter, coll.bsonOpts, coll.registry)
+	if err != nil {
+		return nil, err
+	}
+	op, err := coll.newUpdateOperation(f, update, multi, true, options.MergeUpdateOptions(opts...))
+	if err != nil {
+		return nil, err
+	}
+	return coll.explain(ctx, op, explainOpts, coll.writeSelector, readpref.Primary())
+}
+
+// ExplainDeleteOne executes an explain command for a delete command that would delete at most one document. No
+// documents are deleted. The explainOpts parameter can be used to set the verbosity of the explain command (see the
+// options.ExplainOptions documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/explain/.
+func (coll *Collection) ExplainDeleteOne(ctx context.Context, filter interface{},
+	explainOpts *options.ExplainOptions, opts ...*options.DeleteOptions) (*ExplainResult, error) {
+
+	return coll.explainDelete(ctx, filter, true, explainOpts, opts...)
+}
+
+// ExplainDeleteMany executes an explain command for a delete command that would delete all matching documents. No
+// documents are deleted. The explainOpts parameter can be used to set the verbosity of the explain command (see the
+// options.ExplainOptions documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/explain/.
+func (coll *Collection) ExplainDeleteMany(ctx context.Context, filter interface{},
+	explainOpts *options.ExplainOptions, opts ...*options.DeleteOptions) (*ExplainResult, error) {
+
+	return coll.explainDelete(ctx, filter, false, explainOpts, opts...)
+}
+
+func (coll *Collection) explainDelete(ctx context.Context, filter interface{}, deleteOne bool,
+	explainOpts *options.ExplainOptions, opts ...*options.DeleteOptions) (*ExplainResult, error) {
+
+	f, err := marshal(filter, coll.bsonOpts, coll.registry)
+	if err != nil {
+		return nil, err
+	}
+	op, err := coll.newDeleteOperation(f, deleteOne, options.MergeDeleteOptions(opts...))
+	if err != nil {
+		return nil, err
+	}
+	return coll.explain(ctx, op, explainOpts, coll.writeSelector, readpref.Primary())
+}
+
+// explain wraps the command of the given operation in an explain command, executes it and decodes the server
+// response into an ExplainResult.
+func (coll *Collection) explain(ctx context.Context, explainable operation.Explainable,
+	explainOpts *options.ExplainOptions, selector description.ServerSelector, rp *readpref.ReadPref) (*ExplainResult, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sess := sessionFromContext(ctx)
+	if sess == nil && coll.client.sessionPool != nil {
+		sess = session.NewImplicitClientSession(coll.client.sessionPool, coll.client.id)
+		defer sess.EndSession()
+	}
+
+	err := coll.client.validSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	eo := options.MergeExplainOptions(explainOpts)
+	op := operation.NewExplain(explainable).
+		Session(sess).ClusterClock(coll.client.clock).
+		Database(coll.db.name).CommandMonitor(coll.client.monitor).Interceptors(coll.client.interceptors).
+		Deployment(coll.client.deployment).ReadPreference(rp).
+		ServerSelector(makeReadPrefSelector(sess, selector, coll.client.localThreshold)).
+		Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
+		Timeout(coll.client.timeout).Logger(coll.client.logger)
+
+	if eo.Verbosity != nil {
+		op = op.Verbosity(string(*eo.Verbosity))
+	}
+	if eo.Comment != nil {
+		comment, err := marshalValue(eo.Comment, coll.bsonOpts, coll.registry)
+		if err != nil {
+			return nil, err
+		}
+		op = op.Comment(comment)
+	}
+
+	if err = op.Execute(ctx); err != nil {
+		return nil, replaceErrors(err)
+	}
+
+	raw := bson.Raw(op.Result())
+	res := &ExplainResult{Raw: raw}
+	if err = bson.Unmarshal(raw, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,464 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/bsontype"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+)
+
+// IndexSyncAction is the action taken for one index by an IndexSyncPlan.
+type IndexSyncAction string
+
+// These constants are the actions of an IndexSyncPlan.
+const (
+	// IndexSyncCreate means that the desired index does not exist and will be created.
+	IndexSyncCreate IndexSyncAction = "create"
+
+	// IndexSyncDrop means that the existing index is not in the desired set and will be dropped. Drops are only
+	// planned if the SyncIndexesOptions.DropUnlisted option is set.
+	IndexSyncDrop IndexSyncAction = "drop"
+
+	// IndexSyncUnchanged means that the desired index exists with the desired options.
+	IndexSyncUnchanged IndexSyncAction = "unchanged"
+
+	// IndexSyncConflict means that an existing index has the key pattern or name of the desired index but different
+	// options. It will only be dropped and recreated if the SyncIndexesOptions.ReplaceConflicting option is set.
+	IndexSyncConflict IndexSyncAction = "conflict"
+)
+
+// Options of existing indexes that are never compared because they are not index options or are informational.
+var ignoredIndexSyncOptions = map[string]bool{
+	"key":        true,
+	"name":       true,
+	"ns":         true,
+	"v":          true,
+	"background": true,
+}
+
+// Options that the server fills in with defaults for some index types. They are only compared if the desired index
+// specifies them.
+var defaultedIndexSyncOptions = map[string]bool{
+	"weights":              true,
+	"default_language":     true,
+	"language_override":    true,
+	"textIndexVersion":     true,
+	"2dsphereIndexVersion": true,
+	"bits":                 true,
+	"min":                  true,
+	"max":                  true,
+	"storageEngine":        true,
+}
+
+// IndexSyncStep is the planned action for one index.
+type IndexSyncStep struct {
+	// The action to take.
+	Action IndexSyncAction
+
+	// The name of the index. For creates this is the name the index will be created with.
+	Name string
+
+	// The key pattern of the index.
+	Keys bson.Raw
+
+	// The desired index. This is nil for drops.
+	Model *IndexModel
+
+	// The specification of the existing index as returned by the listIndexes command. This is nil for creates.
+	Existing bson.Raw
+
+	// For conflicts, a description of each option that differs between the desired and the existing index.
+	Differences []string
+}
+
+// IndexSyncPlan is the set of changes needed to make the indexes of a collection match a desired set of indexes. It
+// is returned by IndexView.Sync and can be printed with String or applied with Apply.
+type IndexSyncPlan struct {
+	// The planned action for each desired index, followed by the drops of unlisted existing indexes.
+	Steps []IndexSyncStep
+
+	iv   IndexView
+	opts *options.SyncIndexesOptions
+}
+
+// HasChanges returns true if applying the plan would create or drop any index or if the plan contains conflicts.
+func (p *IndexSyncPlan) HasChanges() bool {
+	for _, step := range p.Steps {
+		if step.Action != IndexSyncUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// Conflicts returns the steps of the plan with the IndexSyncConflict action.
+func (p *IndexSyncPlan) Conflicts() []IndexSyncStep {
+	var conflicts []IndexSyncStep
+	for _, step := range p.Steps {
+		if step.Action == IndexSyncConflict {
+			conflicts = append(conflicts, step)
+		}
+	}
+	return conflicts
+}
+
+// String returns a human-readable description of the plan with one line per step.
+func (p *IndexSyncPlan) String() string {
+	var sb strings.Builder
+	ns := p.iv.coll.db.Name() + "." + p.iv.coll.Name()
+	for _, step := range p.Steps {
+		fmt.Fprintf(&sb, "%s %s %s %s", step.Action, ns, step.Name, step.Keys)
+		if len(step.Differences) > 0 {
+			fmt.Fprintf(&sb, " (%s)", strings.Join(step.Differences, "; "))
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// Apply executes the plan. Indexes are dropped before the missing indexes are created in a single createIndexes
+// command. If the plan contains conflicts and the ReplaceConflicting option was not set, Apply returns an error
+// without making any changes.
+//
+// Apply does not recompute the plan, so it should be called soon after the plan was created.
+func (p *IndexSyncPlan) Apply(ctx context.Context) error {
+	replace := p.opts.ReplaceConflicting != nil && *p.opts.ReplaceConflicting
+
+	var drops []string
+	var creates []IndexModel
+	for _, step := range p.Steps {
+		switch step.Action {
+		case IndexSyncDrop:
+			drops = append(drops, step.Name)
+		case IndexSyncCreate:
+			creates = append(creates, *step.Model)
+		case IndexSyncConflict:
+			if !replace {
+				return fmt.Errorf("index %q conflicts with the desired index: %s", step.Name,
+					strings.Join(step.Differences, "; "))
+			}
+			existing, err := step.Existing.LookupErr("name")
+			if err != nil {
+				return fmt.Errorf("existing index for %q has no name: %w", step.Name, err)
+			}
+			drops = append(drops, existing.StringValue())
+			creates = append(creates, *step.Model)
+		}
+	}
+
+	for _, name := range drops {
+		if _, err := p.iv.DropOne(ctx, name); err != nil {
+			return err
+		}
+	}
+	if len(creates) == 0 {
+		return nil
+	}
+
+	var cio []*options.CreateIndexesOptions
+	if p.opts.CreateIndexesOptions != nil {
+		cio = append(cio, p.opts.CreateIndexesOptions)
+	}
+	_, err := p.iv.CreateMany(ctx, creates, cio...)
+	return err
+}
+
+// Sync reconciles the indexes of the collection with the desired models. It lists the existing indexes, matches each
+// model against them by key pattern and then by name, and compares the options of matched indexes. The resulting plan
+// is applied unless the DryRun option is set, and is returned in both cases.
+//
+// Options that the server fills in with defaults, such as the weights and language options of text indexes, are only
+// compared if the desired model sets them. Text index key patterns are compared in their server form, in which the
+// text fields are replaced by _fts and _ftsx.
+//
+// The opts parameter can be used to specify options for this operation (see the options.SyncIndexesOptions
+// documentation).
+func (iv IndexView) Sync(ctx context.Context, models []IndexModel, opts ...*options.SyncIndexesOptions) (*IndexSyncPlan, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	so := options.MergeSyncIndexesOptions(opts...)
+
+	cursor, err := iv.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var existing []bson.Raw
+	if err := cursor.All(ctx, &existing); err != nil {
+		return nil, err
+	}
+
+	plan := &IndexSyncPlan{iv: iv, opts: so}
+	matched := make(map[int]bool, len(existing))
+	seen := make(map[string]bool, len(models))
+	for i := range models {
+		model := models[i]
+		if model.Keys == nil {
+			return nil, fmt.Errorf("index model keys cannot be nil")
+		}
+		if isUnorderedMap(model.Keys) {
+			return nil, ErrMapForOrderedArgument{"keys"}
+		}
+		keys, err := marshal(model.Keys, iv.coll.bsonOpts, iv.coll.registry)
+		if err != nil {
+			return nil, err
+		}
+		name, err := getOrGenerateIndexName(keys, model)
+		if err != nil {
+			return nil, err
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate desired index %q", name)
+		}
+		seen[name] = true
+
+		var optsDoc bsoncore.Document
+		if model.Options != nil {
+			if optsDoc, err = iv.createOptionsDoc(model.Options); err != nil {
+				return nil, err
+			}
+		}
+
+		serverKeys := serverIndexKeys(keys)
+		explicitName := model.Options != nil && model.Options.Name != nil
+		step := IndexSyncStep{Action: IndexSyncCreate, Name: name, Keys: bson.Raw(keys), Model: &models[i]}
+		if idx := matchExistingIndex(existing, matched, serverKeys, name); idx >= 0 {
+			matched[idx] = true
+			step.Existing = existing[idx]
+			step.Differences = diffIndexOptions(bsoncore.Document(existing[idx]), serverKeys, optsDoc, name,
+				explicitName)
+			step.Action = IndexSyncUnchanged
+			if len(step.Differences) > 0 {
+				step.Action = IndexSyncConflict
+			}
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	if so.DropUnlisted != nil && *so.DropUnlisted {
+		for i, spec := range existing {
+			if matched[i] {
+				continue
+			}
+			name, _ := spec.Lookup("name").StringValueOK()
+			if name == "_id_" {
+				continue
+			}
+			keys, _ := spec.Lookup("key").DocumentOK()
+			plan.Steps = append(plan.Steps, IndexSyncStep{
+				Action:   IndexSyncDrop,
+				Name:     name,
+				Keys:     keys,
+				Existing: spec,
+			})
+		}
+	}
+
+	if so.DryRun != nil && *so.DryRun {
+		return plan, nil
+	}
+	return plan, plan.Apply(ctx)
+}
+
+// matchExistingIndex returns the index in existing of the first unmatched index with the given key pattern, or
+// failing that with the given name, or -1 if there is none.
+func matchExistingIndex(existing []bson.Raw, matched map[int]bool, keys bsoncore.Document, name string) int {
+	for i, spec := range existing {
+		if matched[i] {
+			continue
+		}
+		if ek, ok := bsoncore.Document(spec).Lookup("key").DocumentOK(); ok && indexValuesEqual(
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: ek},
+			bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: keys}) {
+			return i
+		}
+	}
+	for i, spec := range existing {
+		if matched[i] {
+			continue
+		}
+		if en, ok := bsoncore.Document(spec).Lookup("name").StringValueOK(); ok && en == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// serverIndexKeys returns keys in the form reported by listIndexes. For text indexes, the text fields are replaced
+// with {_fts: "text", _ftsx: 1}.
+func serverIndexKeys(keys bsoncore.Document) bsoncore.Document {
+	elems, err := keys.Elements()
+	if err != nil {
+		return keys
+	}
+
+	isText := false
+	for _, elem := range elems {
+		if s, ok := elem.Value().StringValueOK(); ok && s == "text" {
+			isText = true
+			break
+		}
+	}
+	if !isText {
+		return keys
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	addedFTS := false
+	for _, elem := range elems {
+		if s, ok := elem.Value().StringValueOK(); ok && s == "text" {
+			if !addedFTS {
+				doc = bsoncore.AppendStringElement(doc, "_fts", "text")
+				doc = bsoncore.AppendInt32Element(doc, "_ftsx", 1)
+				addedFTS = true
+			}
+			continue
+		}
+		doc = bsoncore.AppendValueElement(doc, elem.Key(), elem.Value())
+	}
+	doc, _ = bsoncore.AppendDocumentEnd(doc, idx)
+	return doc
+}
+
+// diffIndexOptions returns a description of each option that differs between the existing index specification and
+// the desired key pattern, in server form, and options document. The name is only compared if explicitName is true.
+func diffIndexOptions(existing, keys, desired bsoncore.Document, name string, explicitName bool) []string {
+	var diffs []string
+	if en, ok := existing.Lookup("name").StringValueOK(); ok && explicitName && en != name {
+		diffs = append(diffs, fmt.Sprintf("name: desired %q, existing %q", name, en))
+	}
+	if ek, ok := existing.Lookup("key").DocumentOK(); !ok || !indexValuesEqual(
+		bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: ek},
+		bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: keys}) {
+		diffs = append(diffs, fmt.Sprintf("key: desired %s, existing %s", keys, ek))
+	}
+
+	desiredKeys := make(map[string]bool)
+	elems, _ := desired.Elements()
+	for _, elem := range elems {
+		key := elem.Key()
+		desiredKeys[key] = true
+		if key == "name" || key == "background" {
+			continue
+		}
+
+		ev, err := existing.LookupErr(key)
+		switch {
+		case err != nil:
+			if elem.Value().Type == bsontype.Boolean && !elem.Value().Boolean() {
+				continue
+			}
+			diffs = append(diffs, fmt.Sprintf("%s: desired %s, existing <missing>", key, elem.Value()))
+		case key == "collation":
+			if !collationMatches(ev, elem.Value()) {
+				diffs = append(diffs, fmt.Sprintf("%s: desired %s, existing %s", key, elem.Value(), ev))
+			}
+		case !indexValuesEqual(ev, elem.Value()):
+			diffs = append(diffs, fmt.Sprintf("%s: desired %s, existing %s", key, elem.Value(), ev))
+		}
+	}
+
+	elems, _ = existing.Elements()
+	for _, elem := range elems {
+		key := elem.Key()
+		if desiredKeys[key] || ignoredIndexSyncOptions[key] || defaultedIndexSyncOptions[key] {
+			continue
+		}
+		if elem.Value().Type == bsontype.Boolean && !elem.Value().Boolean() {
+			continue
+		}
+		diffs = append(diffs, fmt.Sprintf("%s: desired <missing>, existing %s", key, elem.Value()))
+	}
+
+	sort.Strings(diffs)
+	return diffs
+}
+
+// collationMatches returns true if every field of the desired collation has the same value in the existing collation.
+// The server reports collations with all of their defaults filled in, so fields only in existing are not compared.
+func collationMatches(existing, desired bsoncore.Value) bool {
+	ed, ok := existing.DocumentOK()
+	if !ok {
+		return false
+	}
+	dd, ok := desired.DocumentOK()
+	if !ok {
+		return false
+	}
+	elems, err := dd.Elements()
+	if err != nil {
+		return false
+	}
+	for _, elem := range elems {
+		ev, err := ed.LookupErr(elem.Key())
+		if err != nil || !indexValuesEqual(ev, elem.Value()) {
+			return false
+		}
+	}
+	return true
+}
+
+// indexValuesEqual compares two BSON values, treating numbers of different types as equal if they have the same value.
+// Documents are compared in order.
+func indexValuesEqual(a, b bsoncore.Value) bool {
+	if a.IsNumber() && b.IsNumber() && a.Type != bsontype.Decimal128 && b.Type != bsontype.Decimal128 {
+		return numberAsFloat64(a) == numberAsFloat64(b)
+	}
+	if a.Type != b.Type {
+		return false
+	}
+
+	var av, bv []bsoncore.Value
+	switch a.Type {
+	case bsontype.EmbeddedDocument:
+		ae, err1 := a.Document().Elements()
+		be, err2 := b.Document().Elements()
+		if err1 != nil || err2 != nil || len(ae) != len(be) {
+			return false
+		}
+		for i := range ae {
+			if ae[i].Key() != be[i].Key() || !indexValuesEqual(ae[i].Value(), be[i].Value()) {
+				return false
+			}
+		}
+		return true
+	case bsontype.Array:
+		var err1, err2 error
+		av, err1 = a.Array().Values()
+		bv, err2 = b.Array().Values()
+		if err1 != nil || err2 != nil || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !indexValuesEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return bytes.Equal(a.Data, b.Data)
+	}
+}
+
+func numberAsFloat64(v bsoncore.Value) float64 {
+	switch v.Type {
+	case bsontype.Double:
+		return v.Double()
+	case bsontype.Int32:
+		return float64(v.Int32())
+	default:
+		return float64(v.AsInt64())
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/bsoncodec"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+)
+
+// indexTagModel accumulates the keys and options of the index declared by one or more mongoindex struct tags.
+type indexTagModel struct {
+	keys bson.D
+	opts *options.IndexOptions
+}
+
+// IndexModelsFromStruct returns the indexes declared with mongoindex struct tags on the fields of val, which must be a
+// struct or a pointer to a struct. The result can be passed to IndexView.Sync or IndexView.CreateMany.
+//
+// Field names are resolved with the bson struct tags in the same way as by the default StructCodec. Fields of nested
+// and inlined structs are included with their dotted paths. The mongoindex tag is a comma-separated list of the
+// following flags, and an empty tag declares an ascending single-field index:
+//
+//	unique          the index is unique
+//	sparse          the index is sparse
+//	hidden          the index is hidden from the query planner
+//	desc            the field is indexed in descending order
+//	text            the field is indexed as a text index
+//	2dsphere        the field is indexed as a 2dsphere index
+//	hashed          the field is indexed as a hashed index
+//	expire=<n>      documents expire n seconds after the time in the field
+//	name=<name>     the name of the index
+//	group=<name>    the field is part of the compound index <name>
+//
+// Fields with the same group form a single compound index with the keys in field order. The options of all of the
+// fields in a group are combined, and the index is named after the group unless a name flag is given.
+//
+// For example:
+//
+//	type User struct {
+//		Email     string    `bson:"email" mongoindex:"unique"`
+//		TenantID  string    `bson:"tenantId" mongoindex:"group=tenant_created"`
+//		CreatedAt time.Time `bson:"createdAt" mongoindex:"group=tenant_created,desc"`
+//		Session   time.Time `bson:"session" mongoindex:"expire=3600,sparse"`
+//	}
+func IndexModelsFromStruct(val interface{}) ([]IndexModel, error) {
+	if val == nil {
+		return nil, errors.New("index struct value must not be nil")
+	}
+	t := reflect.TypeOf(val)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("index struct value must be a struct, but was %s", t)
+	}
+
+	var order []string
+	models := make(map[string]*indexTagModel)
+	err := collectIndexTags(t, "", map[reflect.Type]bool{}, func(group, field string, tag string) error {
+		key := group
+		if key == "" {
+			key = "\x00" + field
+		}
+		m, ok := models[key]
+		if !ok {
+			m = &indexTagModel{opts: options.Index()}
+			if group != "" {
+				m.opts.SetName(group)
+			}
+			models[key] = m
+			order = append(order, key)
+		}
+		return m.addField(field, tag)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]IndexModel, 0, len(order))
+	for _, key := range order {
+		m := models[key]
+		result = append(result, IndexModel{Keys: m.keys, Options: m.opts})
+	}
+	return result, nil
+}
+
+// collectIndexTags calls fn for each field of the struct type t that has a mongoindex tag. The prefix is the dotted
+// path of t in the outer document, and visiting records the struct types being visited to stop on recursive types.
+func collectIndexTags(t reflect.Type, prefix string, visiting map[reflect.Type]bool,
+	fn func(group, field, tag string) error) error {
+
+	if visiting[t] {
+		return nil
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		tags, err := bsoncodec.DefaultStructTagParser.ParseStructTags(sf)
+		if err != nil {
+			return err
+		}
+		if tags.Skip {
+			continue
+		}
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		path := prefix
+		if !tags.Inline {
+			path = prefix + tags.Name
+		}
+
+		if tag, ok := sf.Tag.Lookup("mongoindex"); ok {
+			if tags.Inline {
+				return fmt.Errorf("mongoindex tag cannot be used on inlined field %s.%s", t, sf.Name)
+			}
+			if err := fn(indexTagGroup(tag), path, tag); err != nil {
+				return fmt.Errorf("invalid mongoindex tag on field %s.%s: %w", t, sf.Name, err)
+			}
+			continue
+		}
+
+		if ft.Kind() != reflect.Struct || (sf.PkgPath != "" && !tags.Inline) {
+			continue
+		}
+		nested := path + "."
+		if tags.Inline {
+			nested = prefix
+		}
+		if err := collectIndexTags(ft, nested, visiting, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexTagGroup returns the value of the group flag of a mongoindex tag, or "" if there is none.
+func indexTagGroup(tag string) string {
+	for _, flag := range strings.Split(tag, ",") {
+		if g, ok := strings.CutPrefix(strings.TrimSpace(flag), "group="); ok {
+			return g
+		}
+	}
+	return ""
+}
+
+// addField adds field to the index with the flags of its mongoindex tag.
+func (m *indexTagModel) addField(field, tag string) error {
+	var value interface{} = int32(1)
+	for _, flag := range strings.Split(tag, ",") {
+		flag = strings.TrimSpace(flag)
+		name, arg, hasArg := strings.Cut(flag, "=")
+		switch {
+		case flag == "":
+		case flag == "unique":
+			m.opts.SetUnique(true)
+		case flag == "sparse":
+			m.opts.SetSparse(true)
+		case flag == "hidden":
+			m.opts.SetHidden(true)
+		case flag == "desc":
+			value = int32(-1)
+		case flag == "text" || flag == "2dsphere" || flag == "hashed":
+			value = flag
+		case name == "expire" && hasArg:
+			seconds, err := strconv.ParseInt(arg, 10, 32)
+			if err != nil || seconds < 0 {
+				return fmt.Errorf("invalid expire value %q", arg)
+			}
+			m.opts.SetExpireAfterSeconds(int32(seconds))
+		case name == "name" && hasArg && arg != "":
+			m.opts.SetName(arg)
+		case name == "group" && hasArg && arg != "":
+		default:
+			return fmt.Errorf("unknown flag %q", flag)
+		}
+	}
+	m.keys = append(m.keys, bson.E{field, value})
+	return nil
+}
@@ -90,7 +90,7 @@ func (iv IndexView) List(ctx context.Context, opts ...*options.ListIndexesOption
 	// TODO(GODRIVER-3038): This operation should pass CSE to the ListIndexes
 	// Crypt setter to be applied to the operation.
 	op := operation.NewListIndexes().
-		Session(sess).CommandMonitor(iv.coll.client.monitor).
+		Session(sess).CommandMonitor(iv.coll.client.monitor).Interceptors(iv.coll.client.interceptors).
 		ServerSelector(selector).ClusterClock(iv.coll.client.clock).
 		Database(iv.coll.db.name).Collection(iv.coll.name).
 		Deployment(iv.coll.client.deployment).ServerAPI(iv.coll.client.serverAPI).
@@ -261,6 +261,7 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 	op := operation.NewCreateIndexes(indexes).
 		Session(sess).WriteConcern(wc).ClusterClock(iv.coll.client.clock).
 		Database(iv.coll.db.name).Collection(iv.coll.name).CommandMonitor(iv.coll.client.monitor).
+		Interceptors(iv.coll.client.interceptors).
 		Deployment(iv.coll.client.deployment).ServerSelector(selector).ServerAPI(iv.coll.client.serverAPI).
 		Timeout(iv.coll.client.timeout).MaxTime(option.MaxTime)
 	if option.CommitQuorum != nil {
@@ -398,7 +399,7 @@ func (iv IndexView) drop(ctx context.Context, name string, opts ...*options.Drop
 	// TODO(GODRIVER-3038): This operation should pass CSE to the DropIndexes
 	// Crypt setter to be applied to the operation.
 	op := operation.NewDropIndexes(name).
-		Session(sess).WriteConcern(wc).CommandMonitor(iv.coll.client.monitor).
+		Session(sess).WriteConcern(wc).CommandMonitor(iv.coll.client.monitor).Interceptors(iv.coll.client.interceptors).
 		ServerSelector(selector).ClusterClock(iv.coll.client.clock).
 		Database(iv.coll.db.name).Collection(iv.coll.name).
 		Deployment(iv.coll.client.deployment).ServerAPI(iv.coll.client.serverAPI).
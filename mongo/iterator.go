@@ -0,0 +1,121 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+)
+
+// The iterator functions in this file have the same shape as iter.Seq2 so they can be used with range-over-func in
+// Go 1.23 and later, while still compiling with the Go version required by this module.
+
+// decodingIterator is the common subset of Cursor and ChangeStream used by the iterator functions.
+type decodingIterator interface {
+	Next(ctx context.Context) bool
+	Decode(val interface{}) error
+	Err() error
+	Close(ctx context.Context) error
+}
+
+// Documents returns an iterator over the remaining documents in the cursor. Each document is yielded as raw BSON
+// which, like the Current field, is only valid until the next iteration; a copy must be made if continued access is
+// required.
+//
+// Documents are fetched batch by batch as the iteration progresses. If an error occurs while getting the next batch,
+// it is yielded with a nil document and the iteration stops. The cursor is closed when the iteration finishes,
+// including when the caller stops early.
+func (c *Cursor) Documents(ctx context.Context) func(yield func(bson.Raw, error) bool) {
+	return func(yield func(bson.Raw, error) bool) {
+		defer c.Close(context.Background())
+
+		for c.Next(ctx) {
+			if !yield(c.Current, nil) {
+				return
+			}
+		}
+		if err := c.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// Events returns an iterator over the events in the change stream. Each event is yielded as raw BSON which, like the
+// Current field, is only valid until the next iteration; a copy must be made if continued access is required.
+//
+// The iteration blocks waiting for new events and only finishes when ctx expires, the change stream is invalidated, or
+// an error occurs. An error is yielded with a nil event and the iteration stops. The change stream is closed when the
+// iteration finishes, including when the caller stops early.
+func (cs *ChangeStream) Events(ctx context.Context) func(yield func(bson.Raw, error) bool) {
+	return func(yield func(bson.Raw, error) bool) {
+		defer cs.Close(context.Background())
+
+		for cs.Next(ctx) {
+			if !yield(cs.Current, nil) {
+				return
+			}
+		}
+		if err := cs.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// All returns an iterator that decodes each remaining document in cur into a T.
+//
+// If a document cannot be decoded, the zero value of T is yielded together with the decoding error and the iteration
+// continues with the next document unless the caller stops. If an error occurs while getting the next batch, it is
+// yielded and the iteration stops. The cursor is closed when the iteration finishes, including when the caller stops
+// early.
+func All[T any](ctx context.Context, cur *Cursor) func(yield func(T, error) bool) {
+	return decodeAll[T](ctx, cur)
+}
+
+// AllEvents returns an iterator that decodes each event in cs into a T. It has the same error and closing behavior as
+// All, and blocks waiting for new events in the same way as ChangeStream.Events.
+func AllEvents[T any](ctx context.Context, cs *ChangeStream) func(yield func(T, error) bool) {
+	return decodeAll[T](ctx, cs)
+}
+
+// Documents returns an iterator over the remaining documents in the cursor, decoded into values of type T. See All for
+// the error and closing behavior.
+func (tc *TypedCursor[T]) Documents(ctx context.Context) func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		if tc.err != nil {
+			_ = tc.cur.Close(context.Background())
+			var zero T
+			yield(zero, tc.err)
+			return
+		}
+		All[T](ctx, tc.cur)(yield)
+	}
+}
+
+func decodeAll[T any](ctx context.Context, it decodingIterator) func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		defer it.Close(context.Background())
+
+		for it.Next(ctx) {
+			var v T
+			if err := it.Decode(&v); err != nil {
+				var zero T
+				if !yield(zero, err) {
+					return
+				}
+				continue
+			}
+			if !yield(v, nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package lock provides distributed locks with leases, backed by a MongoDB collection. It can be used for mutual
+// exclusion and leader election across processes without any other coordination service.
+//
+// # Locks and leases
+//
+// A Locker stores one document per lock in a collection. Acquiring a lock gives a Lease, which is valid for a limited
+// LeaseDuration and is renewed in the background while the process holds it. If the process dies or loses contact with
+// the deployment, the lease expires and another process can acquire the lock. Lock documents are removed by a TTL index
+// an hour after their lease has expired or been released.
+//
+//	locker, err := lock.NewLocker(ctx, db.Collection("locks"))
+//	if err != nil {
+//		return err
+//	}
+//	lease, err := locker.Acquire(ctx, "nightly-report")
+//	if err != nil {
+//		return err
+//	}
+//	defer lease.Release(context.Background())
+//
+//	// lease.Context() is cancelled if the lease is lost.
+//	return runReport(lease.Context())
+//
+// Cancelling the context passed to Acquire releases the lease.
+//
+// # Fencing tokens
+//
+// Because a lease can expire while its holder is paused, two processes may briefly both believe that they hold a lock.
+// Each lease has a fencing token that is greater than the tokens of all previous leases of the same lock. Passing the
+// token to the protected resource and rejecting requests with a token lower than the highest one seen makes the lock
+// safe in that case.
+//
+// Locks are read and written with a majority read concern and write concern, so an acknowledged lease survives a
+// failover.
+package lock // import "github.com/zhangdapeng520/zdpgo_mongo/mongo/lock"
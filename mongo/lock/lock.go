@@ -0,0 +1,314 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/primitive"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/readconcern"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/writeconcern"
+)
+
+const (
+	// DefaultLeaseDuration is the default amount of time that a lease is valid for.
+	DefaultLeaseDuration = 30 * time.Second
+
+	// DefaultRetryInterval is the default interval at which Acquire retries.
+	DefaultRetryInterval = time.Second
+
+	// expiredLockRetention is the amount of time that a lock document is kept after its lease has ended before it is
+	// removed by the TTL index.
+	expiredLockRetention = time.Hour
+)
+
+// ErrLockHeld is returned by TryAcquire if the lock is held by another lease.
+var ErrLockHeld = errors.New("lock is held by another owner")
+
+// ErrLeaseLost is returned by Lease.Err and Lease.Release if the lease expired or was taken over before it was
+// renewed.
+var ErrLeaseLost = errors.New("lease lost")
+
+// lockDocument is the document that stores the state of a lock.
+type lockDocument struct {
+	Name       string    `bson:"_id"`
+	Lease      string    `bson:"lease"`
+	Owner      string    `bson:"owner"`
+	Token      int64     `bson:"token"`
+	AcquiredAt time.Time `bson:"acquiredAt"`
+	ExpiresAt  time.Time `bson:"expiresAt"`
+}
+
+// Locker acquires leases on locks stored in a collection. It is safe for concurrent use by multiple goroutines.
+type Locker struct {
+	coll          *mongo.Collection
+	owner         string
+	leaseDuration time.Duration
+	renewInterval time.Duration
+	retryInterval time.Duration
+}
+
+// NewLocker creates a Locker that stores locks in coll, and creates the TTL index that removes lock documents an hour
+// after their lease has ended. The collection is used with a majority read concern and write concern regardless of its
+// own settings.
+//
+// The opts parameter can be used to specify options for the locker (see the options.LockerOptions documentation).
+func NewLocker(ctx context.Context, coll *mongo.Collection, opts ...*options.LockerOptions) (*Locker, error) {
+	lo := options.MergeLockerOptions(opts...)
+
+	l := &Locker{
+		leaseDuration: DefaultLeaseDuration,
+		retryInterval: DefaultRetryInterval,
+	}
+	if lo.Owner != nil {
+		l.owner = *lo.Owner
+	} else {
+		host, _ := os.Hostname()
+		l.owner = fmt.Sprintf("%s:%d", host, os.Getpid())
+	}
+	if lo.LeaseDuration != nil {
+		if *lo.LeaseDuration < time.Millisecond {
+			return nil, fmt.Errorf("lease duration must be at least 1ms, but was %v", *lo.LeaseDuration)
+		}
+		l.leaseDuration = *lo.LeaseDuration
+	}
+	l.renewInterval = l.leaseDuration / 3
+	if lo.RenewInterval != nil {
+		if *lo.RenewInterval <= 0 || *lo.RenewInterval >= l.leaseDuration {
+			return nil, fmt.Errorf("renew interval must be positive and shorter than the lease duration, but was %v",
+				*lo.RenewInterval)
+		}
+		l.renewInterval = *lo.RenewInterval
+	}
+	if lo.RetryInterval != nil {
+		if *lo.RetryInterval <= 0 {
+			return nil, fmt.Errorf("retry interval must be positive, but was %v", *lo.RetryInterval)
+		}
+		l.retryInterval = *lo.RetryInterval
+	}
+
+	var err error
+	l.coll, err = coll.Clone(options.Collection().
+		SetReadConcern(readconcern.Majority()).
+		SetWriteConcern(writeconcern.Majority()))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = l.coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{"expiresAt", 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(expiredLockRetention / time.Second)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Acquire acquires the lock called name, waiting for it to be released or to expire while it is held by another
+// lease. It returns the context error if ctx is done first. Once acquired, the lease is renewed in the background until
+// it is released, and is released when ctx is done.
+func (l *Locker) Acquire(ctx context.Context, name string) (*Lease, error) {
+	for {
+		lease, err := l.TryAcquire(ctx, name)
+		if !errors.Is(err, ErrLockHeld) {
+			return lease, err
+		}
+
+		timer := time.NewTimer(l.retryInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// TryAcquire acquires the lock called name if it is not held by another lease, and returns ErrLockHeld otherwise.
+// Once acquired, the lease is renewed in the background until it is released, and is released when ctx is done.
+func (l *Locker) TryAcquire(ctx context.Context, name string) (*Lease, error) {
+	id := primitive.NewObjectID().Hex()
+	start := time.Now()
+
+	// The lock can be taken if it does not exist or its lease has expired according to the clock of the server. The
+	// fencing token is incremented, and is at least the current time in milliseconds so that it keeps increasing
+	// after a lock document has been removed by the TTL index.
+	filter := bson.D{
+		{"_id", name},
+		{"$expr", bson.D{{"$lte", bson.A{"$expiresAt", "$$NOW"}}}},
+	}
+	update := mongo.Pipeline{
+		{{"$set", bson.D{
+			{"lease", id},
+			{"owner", l.owner},
+			{"token", bson.D{{"$max", bson.A{
+				bson.D{{"$add", bson.A{bson.D{{"$ifNull", bson.A{"$token", int64(0)}}}, int64(1)}}},
+				bson.D{{"$toLong", "$$NOW"}},
+			}}}},
+			{"acquiredAt", "$$NOW"},
+			{"expiresAt", bson.D{{"$add", bson.A{"$$NOW", l.leaseDuration.Milliseconds()}}}},
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var doc lockDocument
+	err := l.coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrLockHeld
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lease := &Lease{
+		locker:   l,
+		name:     name,
+		id:       id,
+		token:    doc.Token,
+		deadline: start.Add(l.leaseDuration),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	lease.ctx, lease.cancel = context.WithCancel(ctx)
+	go lease.heartbeat(ctx)
+	return lease, nil
+}
+
+// Lease is a lease on a lock acquired by a Locker. It is safe for concurrent use by multiple goroutines.
+type Lease struct {
+	locker *Locker
+	name   string
+	id     string
+	token  int64
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   chan struct{}
+	done   chan struct{}
+
+	mu       sync.Mutex
+	deadline time.Time
+	err      error
+	stopOnce sync.Once
+}
+
+// Name returns the name of the lock.
+func (le *Lease) Name() string {
+	return le.name
+}
+
+// Token returns the fencing token of the lease. It is greater than the tokens of all previous leases on the same lock.
+func (le *Lease) Token() int64 {
+	return le.token
+}
+
+// Context returns a context that is cancelled when the lease is lost or released, or when the context passed to
+// Acquire is done.
+func (le *Lease) Context() context.Context {
+	return le.ctx
+}
+
+// Done returns a channel that is closed once the lease is no longer renewed because it was lost or released.
+func (le *Lease) Done() <-chan struct{} {
+	return le.done
+}
+
+// Err returns ErrLeaseLost if the lease was lost, and nil otherwise.
+func (le *Lease) Err() error {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	return le.err
+}
+
+// Release stops renewing the lease and releases the lock so that it can be acquired by another lease. It returns
+// ErrLeaseLost if the lease had already been lost. Release is idempotent.
+func (le *Lease) Release(ctx context.Context) error {
+	le.stopOnce.Do(func() { close(le.stop) })
+	<-le.done
+
+	if err := le.Err(); err != nil {
+		return err
+	}
+	return le.release(ctx)
+}
+
+// heartbeat renews the lease until it is released or lost, and releases it when ctx is done.
+func (le *Lease) heartbeat(ctx context.Context) {
+	defer close(le.done)
+	defer le.cancel()
+
+	ticker := time.NewTicker(le.locker.renewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := le.renew(); err != nil {
+				le.mu.Lock()
+				le.err = ErrLeaseLost
+				le.mu.Unlock()
+				return
+			}
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.Background(), le.locker.leaseDuration)
+			_ = le.release(releaseCtx)
+			cancel()
+			return
+		case <-le.stop:
+			return
+		}
+	}
+}
+
+// renew extends the lease. It returns an error once the lease is lost, either because it was taken over or because it
+// could not be renewed before it expired. Other errors are retried at the next heartbeat.
+func (le *Lease) renew() error {
+	le.mu.Lock()
+	deadline := le.deadline
+	le.mu.Unlock()
+
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	start := time.Now()
+	filter := bson.D{{"_id", le.name}, {"lease", le.id}}
+	update := mongo.Pipeline{
+		{{"$set", bson.D{{"expiresAt", bson.D{{"$add", bson.A{"$$NOW", le.locker.leaseDuration.Milliseconds()}}}}}}},
+	}
+	res, err := le.locker.coll.UpdateOne(ctx, filter, update)
+	switch {
+	case err == nil && res.MatchedCount == 0:
+		return ErrLeaseLost
+	case err == nil:
+		le.mu.Lock()
+		le.deadline = start.Add(le.locker.leaseDuration)
+		le.mu.Unlock()
+		return nil
+	case time.Now().After(deadline):
+		return ErrLeaseLost
+	default:
+		return nil
+	}
+}
+
+// release ends the lease if it still holds the lock. The lock document is left for the TTL index to remove, so that
+// the next lease acquired in the meantime gets its fencing token from the counter instead of the clock.
+func (le *Lease) release(ctx context.Context) error {
+	update := bson.D{
+		{"$currentDate", bson.D{{"expiresAt", true}}},
+		{"$unset", bson.D{{"lease", ""}}},
+	}
+	_, err := le.locker.coll.UpdateOne(ctx, bson.D{{"_id", le.name}, {"lease", le.id}}, update)
+	return err
+}
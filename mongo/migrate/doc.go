@@ -0,0 +1,51 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package migrate runs versioned schema and data migrations against a MongoDB database and records which ones have
+// been applied.
+//
+// # Migrations
+//
+// A migration is a pair of Go functions: Up applies it and Down rolls it back. Migrations are registered with a
+// Migrator in increasing version order, and Up applies each pending migration once, in that order. The applied versions
+// are recorded in the migrations collection of the database.
+//
+//	m := migrate.NewMigrator(client.Database("app"))
+//	err := m.Register(migrate.Migration{
+//		Version:     1,
+//		Description: "index users by email",
+//		Up: func(ctx context.Context, db *mongo.Database) error {
+//			_, err := db.Collection("users").Indexes().CreateOne(ctx, mongo.IndexModel{
+//				Keys:    bson.D{{"email", 1}},
+//				Options: options.Index().SetUnique(true),
+//			})
+//			return err
+//		},
+//		Down: func(ctx context.Context, db *mongo.Database) error {
+//			_, err := db.Collection("users").Indexes().DropOne(ctx, "email_1")
+//			return err
+//		},
+//	})
+//	...
+//	applied, err := m.Up(ctx)
+//
+// Up and Down accept a dry run option that returns the migrations that would run without running them, and Status
+// reports which registered migrations have been applied.
+//
+// # Concurrency
+//
+// Runners in different processes are serialized with a lock from package lock, so a migration is not applied twice
+// when several instances of an application start at the same time. The context passed to migration functions is
+// cancelled if the lock is lost.
+//
+// # Transactions
+//
+// A migration with Transaction set runs inside a transaction together with the update of the migrations collection, so
+// that it is either fully applied and recorded or not applied at all. Transactions are only used if the deployment is a
+// replica set or a sharded cluster; on a standalone server, the migration runs without a transaction. Migrations that
+// run in a transaction must use the context passed to them for all of their operations, and may be retried if the
+// transaction fails with a transient error.
+package migrate // import "github.com/zhangdapeng520/zdpgo_mongo/mongo/migrate"
@@ -0,0 +1,355 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/lock"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+)
+
+const (
+	// DefaultCollection is the default name of the collection that records the applied migrations.
+	DefaultCollection = "migrations"
+
+	// lockName is the name of the lock that serializes runners.
+	lockName = "migrations"
+)
+
+// ErrIrreversible is returned by Down if a migration that must be rolled back has no Down function.
+var ErrIrreversible = errors.New("migration cannot be rolled back")
+
+// MigrationFunc applies or rolls back a migration on db. If the migration runs in a transaction, ctx carries the
+// session and must be used for all operations.
+type MigrationFunc func(ctx context.Context, db *mongo.Database) error
+
+// Migration is a versioned migration.
+type Migration struct {
+	// Version is the version of the migration. It must be positive and greater than the versions of the migrations
+	// registered before it.
+	Version int64
+
+	// Description describes the migration. It is recorded in the migrations collection.
+	Description string
+
+	// Up applies the migration. It is required.
+	Up MigrationFunc
+
+	// Down rolls back the migration. If it is nil, the migration cannot be rolled back.
+	Down MigrationFunc
+
+	// Transaction specifies whether the migration runs in a transaction if the deployment supports it.
+	Transaction bool
+}
+
+// Status is the status of a migration.
+type Status struct {
+	// Version is the version of the migration.
+	Version int64
+
+	// Description is the description of the migration.
+	Description string
+
+	// Applied is true if the migration has been applied.
+	Applied bool
+
+	// AppliedAt is the time at which the migration was applied, or the zero time if it has not been applied.
+	AppliedAt time.Time
+
+	// Unregistered is true if the migration has been applied but is not registered with the Migrator, for example
+	// because it was applied by a newer version of the application.
+	Unregistered bool
+}
+
+// record is the document that records an applied migration.
+type record struct {
+	Version     int64     `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"appliedAt"`
+}
+
+// Migrator registers migrations and applies them to a database. It is safe for concurrent use by multiple goroutines.
+type Migrator struct {
+	db         *mongo.Database
+	coll       *mongo.Collection
+	lockColl   *mongo.Collection
+	lockerOpts *options.LockerOptions
+
+	mu         sync.Mutex
+	migrations []Migration
+}
+
+// NewMigrator creates a Migrator for db.
+//
+// The opts parameter can be used to specify options for the migrator (see the options.MigratorOptions documentation).
+func NewMigrator(db *mongo.Database, opts ...*options.MigratorOptions) *Migrator {
+	mo := options.MergeMigratorOptions(opts...)
+
+	name := DefaultCollection
+	if mo.Collection != nil {
+		name = *mo.Collection
+	}
+	lockCollName := name + "_lock"
+	if mo.LockCollection != nil {
+		lockCollName = *mo.LockCollection
+	}
+	return &Migrator{
+		db:         db,
+		coll:       db.Collection(name),
+		lockColl:   db.Collection(lockCollName),
+		lockerOpts: mo.LockerOptions,
+	}
+}
+
+// Register adds a migration. Migrations must be registered in increasing version order.
+func (m *Migrator) Register(migration Migration) error {
+	if migration.Version <= 0 {
+		return fmt.Errorf("migration version must be positive, but was %d", migration.Version)
+	}
+	if migration.Up == nil {
+		return fmt.Errorf("migration %d has no Up function", migration.Version)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if n := len(m.migrations); n > 0 && migration.Version <= m.migrations[n-1].Version {
+		return fmt.Errorf("migration %d must have a greater version than migration %d, which was registered before it",
+			migration.Version, m.migrations[n-1].Version)
+	}
+	m.migrations = append(m.migrations, migration)
+	return nil
+}
+
+// Up applies the pending migrations in version order and returns them. If a migration fails, the migrations applied
+// before it are returned along with the error.
+//
+// The opts parameter can be used to specify the target version and a dry run (see the options.MigrateOptions
+// documentation).
+func (m *Migrator) Up(ctx context.Context, opts ...*options.MigrateOptions) ([]Migration, error) {
+	mo := options.MergeMigrateOptions(opts...)
+	return m.migrate(ctx, mo, true)
+}
+
+// Down rolls back the applied migrations in reverse version order and returns them. If a migration fails, the
+// migrations rolled back before it are returned along with the error.
+//
+// The opts parameter can be used to specify the target version and a dry run (see the options.MigrateOptions
+// documentation).
+func (m *Migrator) Down(ctx context.Context, opts ...*options.MigrateOptions) ([]Migration, error) {
+	mo := options.MergeMigrateOptions(opts...)
+	return m.migrate(ctx, mo, false)
+}
+
+// Status returns the status of the registered migrations and of the applied migrations that are not registered, in
+// version order.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []Status
+	for _, migration := range m.registered() {
+		status := Status{Version: migration.Version, Description: migration.Description}
+		if rec, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = rec.AppliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, rec := range applied {
+		statuses = append(statuses, Status{
+			Version:      rec.Version,
+			Description:  rec.Description,
+			Applied:      true,
+			AppliedAt:    rec.AppliedAt,
+			Unregistered: true,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// migrate applies or rolls back migrations while holding the lock.
+func (m *Migrator) migrate(ctx context.Context, mo *options.MigrateOptions, up bool) (done []Migration, err error) {
+	if mo.DryRun != nil && *mo.DryRun {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return m.plan(applied, mo.Target, up)
+	}
+
+	locker, err := lock.NewLocker(ctx, m.lockColl, m.lockerOpts)
+	if err != nil {
+		return nil, err
+	}
+	lease, err := locker.Acquire(ctx, lockName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if releaseErr := lease.Release(context.Background()); err == nil {
+			err = releaseErr
+		}
+	}()
+	ctx = lease.Context()
+
+	// The applied migrations are read once the lock is held, so that migrations applied by another runner are seen.
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := m.plan(applied, mo.Target, up)
+	if err != nil || len(plan) == 0 {
+		return nil, err
+	}
+	transactions, err := m.supportsTransactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, migration := range plan {
+		if err := m.run(ctx, migration, up, transactions); err != nil {
+			action := "applying"
+			if !up {
+				action = "rolling back"
+			}
+			return plan[:i], fmt.Errorf("error %s migration %d (%s): %w", action, migration.Version,
+				migration.Description, err)
+		}
+	}
+	return plan, nil
+}
+
+// plan returns the migrations to apply or roll back, in the order in which they must run.
+func (m *Migrator) plan(applied map[int64]record, target *int64, up bool) ([]Migration, error) {
+	registered := m.registered()
+
+	var plan []Migration
+	if up {
+		for _, migration := range registered {
+			if target != nil && migration.Version > *target {
+				break
+			}
+			if _, ok := applied[migration.Version]; !ok {
+				plan = append(plan, migration)
+			}
+		}
+		return plan, nil
+	}
+
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] > versions[j] })
+	if target == nil && len(versions) > 1 {
+		versions = versions[:1]
+	}
+
+	byVersion := make(map[int64]Migration, len(registered))
+	for _, migration := range registered {
+		byVersion[migration.Version] = migration
+	}
+	for _, version := range versions {
+		if target != nil && version <= *target {
+			break
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			return nil, fmt.Errorf("migration %d is applied but not registered", version)
+		}
+		if migration.Down == nil {
+			return nil, fmt.Errorf("migration %d (%s): %w", version, migration.Description, ErrIrreversible)
+		}
+		plan = append(plan, migration)
+	}
+	return plan, nil
+}
+
+// run applies or rolls back a migration and updates the migrations collection, in a transaction if the migration
+// requests it and the deployment supports it.
+func (m *Migrator) run(ctx context.Context, migration Migration, up, transactions bool) error {
+	fn := migration.Up
+	if !up {
+		fn = migration.Down
+	}
+
+	apply := func(ctx context.Context) error {
+		if err := fn(ctx, m.db); err != nil {
+			return err
+		}
+		if !up {
+			_, err := m.coll.DeleteOne(ctx, bson.D{{"_id", migration.Version}})
+			return err
+		}
+		_, err := m.coll.InsertOne(ctx, record{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now(),
+		})
+		return err
+	}
+
+	if !migration.Transaction || !transactions {
+		return apply(ctx)
+	}
+	return m.db.Client().UseSession(ctx, func(sc mongo.SessionContext) error {
+		_, err := sc.WithTransaction(sc, func(sc mongo.SessionContext) (interface{}, error) {
+			return nil, apply(sc)
+		})
+		return err
+	})
+}
+
+// applied returns the applied migrations by version.
+func (m *Migrator) applied(ctx context.Context) (map[int64]record, error) {
+	cursor, err := m.coll.Find(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	var records []record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]record, len(records))
+	for _, rec := range records {
+		applied[rec.Version] = rec
+	}
+	return applied, nil
+}
+
+// registered returns a copy of the registered migrations.
+func (m *Migrator) registered() []Migration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Migration(nil), m.migrations...)
+}
+
+// supportsTransactions reports whether the deployment is a replica set or a sharded cluster that supports sessions.
+func (m *Migrator) supportsTransactions(ctx context.Context) (bool, error) {
+	var hello struct {
+		SetName                      string `bson:"setName"`
+		Msg                          string `bson:"msg"`
+		LogicalSessionTimeoutMinutes *int64 `bson:"logicalSessionTimeoutMinutes"`
+	}
+	if err := m.db.RunCommand(ctx, bson.D{{"hello", 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+	return hello.LogicalSessionTimeoutMinutes != nil && (hello.SetName != "" || hello.Msg == "isdbgrid"), nil
+}
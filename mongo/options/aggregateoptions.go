@@ -59,6 +59,12 @@ type AggregateOptions struct {
 	// accessed as variables in an aggregate expression context (e.g. "$$var").
 	Let interface{}
 
+	// The maximum number of batches that are fetched with getMore commands in the background while the caller
+	// processes the current batch. Prefetching only applies to cursors whose operation does not use an explicit
+	// session. The default value is 0, which means that the next batch is only fetched once the current batch is
+	// exhausted.
+	PrefetchBatches *int32
+
 	// Custom options to be added to aggregate expression. Key-value pairs of the BSON map should correlate with desired
 	// option names and values. Values must be Marshalable. Custom options may conflict with non-custom options, and custom
 	// options bypass client-side validation. Prefer using non-custom options where possible.
@@ -128,6 +134,12 @@ func (ao *AggregateOptions) SetLet(let interface{}) *AggregateOptions {
 	return ao
 }
 
+// SetPrefetchBatches sets the value for the PrefetchBatches field.
+func (ao *AggregateOptions) SetPrefetchBatches(i int32) *AggregateOptions {
+	ao.PrefetchBatches = &i
+	return ao
+}
+
 // SetCustom sets the value for the Custom field. Key-value pairs of the BSON map should correlate
 // with desired option names and values. Values must be Marshalable. Custom options may conflict
 // with non-custom options, and custom options bypass client-side validation. Prefer using non-custom
@@ -175,6 +187,9 @@ func MergeAggregateOptions(opts ...*AggregateOptions) *AggregateOptions {
 		if ao.Let != nil {
 			aggOpts.Let = ao.Let
 		}
+		if ao.PrefetchBatches != nil {
+			aggOpts.PrefetchBatches = ao.PrefetchBatches
+		}
 		if ao.Custom != nil {
 			aggOpts.Custom = ao.Custom
 		}
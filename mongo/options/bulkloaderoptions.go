@@ -0,0 +1,96 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import "time"
+
+// BulkLoaderOptions represents options that can be used to configure a BulkLoader.
+type BulkLoaderOptions struct {
+	// The maximum number of write models in a batch. The default value is 1000.
+	BatchSize *int
+
+	// The maximum total size in bytes of the documents in a batch. The size is also limited by the maxMessageSizeBytes
+	// reported by the server. The default value is nil, which means that only the server limit applies.
+	MaxBatchBytes *int64
+
+	// The interval at which partial batches are written. The default value is nil, which means that partial batches are
+	// only written by Flush and Close.
+	FlushInterval *time.Duration
+
+	// The maximum number of batches that are written concurrently. The default value is 1.
+	Concurrency *int
+
+	// Options used for the bulk write of each batch. If Ordered is not set, batches are written unordered. Ordering
+	// only applies within a batch, because batches are written concurrently. The default value is nil.
+	BulkWriteOptions *BulkWriteOptions
+}
+
+// BulkLoader creates a new BulkLoaderOptions instance.
+func BulkLoader() *BulkLoaderOptions {
+	return &BulkLoaderOptions{}
+}
+
+// SetBatchSize sets the value for the BatchSize field.
+func (blo *BulkLoaderOptions) SetBatchSize(size int) *BulkLoaderOptions {
+	blo.BatchSize = &size
+	return blo
+}
+
+// SetMaxBatchBytes sets the value for the MaxBatchBytes field.
+func (blo *BulkLoaderOptions) SetMaxBatchBytes(size int64) *BulkLoaderOptions {
+	blo.MaxBatchBytes = &size
+	return blo
+}
+
+// SetFlushInterval sets the value for the FlushInterval field.
+func (blo *BulkLoaderOptions) SetFlushInterval(d time.Duration) *BulkLoaderOptions {
+	blo.FlushInterval = &d
+	return blo
+}
+
+// SetConcurrency sets the value for the Concurrency field.
+func (blo *BulkLoaderOptions) SetConcurrency(n int) *BulkLoaderOptions {
+	blo.Concurrency = &n
+	return blo
+}
+
+// SetBulkWriteOptions sets the value for the BulkWriteOptions field.
+func (blo *BulkLoaderOptions) SetBulkWriteOptions(opts *BulkWriteOptions) *BulkLoaderOptions {
+	blo.BulkWriteOptions = opts
+	return blo
+}
+
+// MergeBulkLoaderOptions combines the given BulkLoaderOptions instances into a single BulkLoaderOptions in a
+// last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeBulkLoaderOptions(opts ...*BulkLoaderOptions) *BulkLoaderOptions {
+	blo := BulkLoader()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.BatchSize != nil {
+			blo.BatchSize = opt.BatchSize
+		}
+		if opt.MaxBatchBytes != nil {
+			blo.MaxBatchBytes = opt.MaxBatchBytes
+		}
+		if opt.FlushInterval != nil {
+			blo.FlushInterval = opt.FlushInterval
+		}
+		if opt.Concurrency != nil {
+			blo.Concurrency = opt.Concurrency
+		}
+		if opt.BulkWriteOptions != nil {
+			blo.BulkWriteOptions = opt.BulkWriteOptions
+		}
+	}
+
+	return blo
+}
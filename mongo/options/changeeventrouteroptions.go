@@ -0,0 +1,57 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// ChangeEventRouterOptions represents options that can be used to configure a ChangeEventRouter.
+type ChangeEventRouterOptions struct {
+	// The maximum number of events handled concurrently by ChangeEventRouter.Run. The default value is 1.
+	Concurrency *int
+
+	// If true, the events of the same document, identified by their documentKey, are handled in the order of the change
+	// stream, and events without a documentKey, such as DDL events, are handled after all previous events and before
+	// any later event. If false, events are handled in any order. The default value is true.
+	OrderByDocumentKey *bool
+}
+
+// ChangeEventRouter creates a new ChangeEventRouterOptions instance.
+func ChangeEventRouter() *ChangeEventRouterOptions {
+	return &ChangeEventRouterOptions{}
+}
+
+// SetConcurrency sets the value for the Concurrency field.
+func (cero *ChangeEventRouterOptions) SetConcurrency(n int) *ChangeEventRouterOptions {
+	cero.Concurrency = &n
+	return cero
+}
+
+// SetOrderByDocumentKey sets the value for the OrderByDocumentKey field.
+func (cero *ChangeEventRouterOptions) SetOrderByDocumentKey(b bool) *ChangeEventRouterOptions {
+	cero.OrderByDocumentKey = &b
+	return cero
+}
+
+// MergeChangeEventRouterOptions combines the given ChangeEventRouterOptions instances into a single
+// ChangeEventRouterOptions in a last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeChangeEventRouterOptions(opts ...*ChangeEventRouterOptions) *ChangeEventRouterOptions {
+	cero := ChangeEventRouter()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Concurrency != nil {
+			cero.Concurrency = opt.Concurrency
+		}
+		if opt.OrderByDocumentKey != nil {
+			cero.OrderByDocumentKey = opt.OrderByDocumentKey
+		}
+	}
+
+	return cero
+}
@@ -0,0 +1,122 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import "time"
+
+// HistoryLostPolicy specifies what a ChangeStreamConsumer does when its checkpoint can no longer be resumed from,
+// because the resume token is invalid or the oplog entry it refers to has rolled off.
+type HistoryLostPolicy string
+
+// These constants specify valid values for HistoryLostPolicy.
+const (
+	// HistoryLostFail makes Run return the error. The checkpoint is left unchanged.
+	HistoryLostFail HistoryLostPolicy = "fail"
+	// HistoryLostRestart discards the checkpoint and restarts the change stream from the current time. The events
+	// between the checkpoint and the restart are not processed.
+	HistoryLostRestart HistoryLostPolicy = "restart"
+)
+
+// ChangeStreamConsumerOptions represents options that can be used to configure a ChangeStreamConsumer.
+type ChangeStreamConsumerOptions struct {
+	// The aggregation pipeline of the change stream. The default value is nil, which means that all events are
+	// consumed.
+	Pipeline interface{}
+
+	// Options used to open the change stream. ResumeAfter, StartAfter and StartAtOperationTime are only used if there
+	// is no checkpoint. The default value is nil.
+	ChangeStreamOptions *ChangeStreamOptions
+
+	// The number of acknowledged events after which a checkpoint is saved. The default value is 100.
+	CheckpointEvents *int
+
+	// The maximum amount of time between checkpoints while events are acknowledged or the change stream is idle. The
+	// default value is 5 seconds.
+	CheckpointInterval *time.Duration
+
+	// What to do when the checkpoint can no longer be resumed from. The default value is HistoryLostFail.
+	HistoryLostPolicy *HistoryLostPolicy
+
+	// A function called with the error when the checkpoint can no longer be resumed from, before the HistoryLostPolicy
+	// is applied. The default value is nil.
+	OnHistoryLost func(err error)
+}
+
+// ChangeStreamConsumer creates a new ChangeStreamConsumerOptions instance.
+func ChangeStreamConsumer() *ChangeStreamConsumerOptions {
+	return &ChangeStreamConsumerOptions{}
+}
+
+// SetPipeline sets the value for the Pipeline field.
+func (csco *ChangeStreamConsumerOptions) SetPipeline(pipeline interface{}) *ChangeStreamConsumerOptions {
+	csco.Pipeline = pipeline
+	return csco
+}
+
+// SetChangeStreamOptions sets the value for the ChangeStreamOptions field.
+func (csco *ChangeStreamConsumerOptions) SetChangeStreamOptions(opts *ChangeStreamOptions) *ChangeStreamConsumerOptions {
+	csco.ChangeStreamOptions = opts
+	return csco
+}
+
+// SetCheckpointEvents sets the value for the CheckpointEvents field.
+func (csco *ChangeStreamConsumerOptions) SetCheckpointEvents(n int) *ChangeStreamConsumerOptions {
+	csco.CheckpointEvents = &n
+	return csco
+}
+
+// SetCheckpointInterval sets the value for the CheckpointInterval field.
+func (csco *ChangeStreamConsumerOptions) SetCheckpointInterval(d time.Duration) *ChangeStreamConsumerOptions {
+	csco.CheckpointInterval = &d
+	return csco
+}
+
+// SetHistoryLostPolicy sets the value for the HistoryLostPolicy field.
+func (csco *ChangeStreamConsumerOptions) SetHistoryLostPolicy(policy HistoryLostPolicy) *ChangeStreamConsumerOptions {
+	csco.HistoryLostPolicy = &policy
+	return csco
+}
+
+// SetOnHistoryLost sets the value for the OnHistoryLost field.
+func (csco *ChangeStreamConsumerOptions) SetOnHistoryLost(fn func(err error)) *ChangeStreamConsumerOptions {
+	csco.OnHistoryLost = fn
+	return csco
+}
+
+// MergeChangeStreamConsumerOptions combines the given ChangeStreamConsumerOptions instances into a single
+// ChangeStreamConsumerOptions in a last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeChangeStreamConsumerOptions(opts ...*ChangeStreamConsumerOptions) *ChangeStreamConsumerOptions {
+	csco := ChangeStreamConsumer()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Pipeline != nil {
+			csco.Pipeline = opt.Pipeline
+		}
+		if opt.ChangeStreamOptions != nil {
+			csco.ChangeStreamOptions = opt.ChangeStreamOptions
+		}
+		if opt.CheckpointEvents != nil {
+			csco.CheckpointEvents = opt.CheckpointEvents
+		}
+		if opt.CheckpointInterval != nil {
+			csco.CheckpointInterval = opt.CheckpointInterval
+		}
+		if opt.HistoryLostPolicy != nil {
+			csco.HistoryLostPolicy = opt.HistoryLostPolicy
+		}
+		if opt.OnHistoryLost != nil {
+			csco.OnHistoryLost = opt.OnHistoryLost
+		}
+	}
+
+	return csco
+}
@@ -43,6 +43,12 @@ type ChangeStreamOptions struct {
 	// StartAfter must not be set.
 	ResumeAfter interface{}
 
+	// If true, a $changeStreamSplitLargeEvent stage is appended to the pipeline so that events larger than 16MB are
+	// split into fragments by the server, and the fragments are merged back into a single event by Next and TryNext.
+	// The resume token of a merged event is the one of its last fragment. This option is only valid for MongoDB
+	// versions >= 7.0. The default value is nil, which means that events are not split.
+	ReassembleSplitEvents *bool
+
 	// ShowExpandedEvents specifies whether the server will return an expanded list of change stream events. Additional
 	// events include: createIndexes, dropIndexes, modify, create, shardCollection, reshardCollection and
 	// refineCollectionShardKey. This option is only valid for MongoDB versions >= 6.0.
@@ -119,6 +125,12 @@ func (cso *ChangeStreamOptions) SetResumeAfter(rt interface{}) *ChangeStreamOpti
 	return cso
 }
 
+// SetReassembleSplitEvents sets the value for the ReassembleSplitEvents field.
+func (cso *ChangeStreamOptions) SetReassembleSplitEvents(b bool) *ChangeStreamOptions {
+	cso.ReassembleSplitEvents = &b
+	return cso
+}
+
 // SetShowExpandedEvents sets the value for the ShowExpandedEvents field.
 func (cso *ChangeStreamOptions) SetShowExpandedEvents(see bool) *ChangeStreamOptions {
 	cso.ShowExpandedEvents = &see
@@ -186,6 +198,9 @@ func MergeChangeStreamOptions(opts ...*ChangeStreamOptions) *ChangeStreamOptions
 		if cso.ResumeAfter != nil {
 			csOpts.ResumeAfter = cso.ResumeAfter
 		}
+		if cso.ReassembleSplitEvents != nil {
+			csOpts.ReassembleSplitEvents = cso.ReassembleSplitEvents
+		}
 		if cso.ShowExpandedEvents != nil {
 			csOpts.ShowExpandedEvents = cso.ShowExpandedEvents
 		}
@@ -0,0 +1,120 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import (
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/writeconcern"
+)
+
+// ClientBulkWriteOptions represents options that can be used to configure a Client.BulkWrite operation.
+type ClientBulkWriteOptions struct {
+	// If true, writes executed as part of the operation will opt out of document-level validation on the server. The
+	// default value is false. See https://www.mongodb.com/docs/manual/core/schema-validation/ for more information
+	// about document validation.
+	BypassDocumentValidation *bool
+
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation.  The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
+	// If true, no writes will be executed after one fails. Ordered writes cannot be used with an unacknowledged write
+	// concern. The default value is nil, which means that writes are ordered, or unordered if the write concern is
+	// unacknowledged.
+	Ordered *bool
+
+	// Specifies parameters for all update and delete writes in the operation. This must be a document mapping
+	// parameter names to values. Values must be constant or closed expressions that do not reference document fields.
+	// Parameters can then be accessed as variables in an aggregate expression context (e.g. "$$var").
+	Let interface{}
+
+	// The write concern to use for the operation. The default value is nil, which means that the write concern of the
+	// Client will be used.
+	WriteConcern *writeconcern.WriteConcern
+
+	// If true, the result of each individual write will be reported in the InsertResults, UpdateResults and
+	// DeleteResults fields of the ClientBulkWriteResult. The default value is false, which means that only the summary
+	// and per-namespace counts are reported.
+	VerboseResults *bool
+}
+
+// ClientBulkWrite creates a new *ClientBulkWriteOptions instance.
+func ClientBulkWrite() *ClientBulkWriteOptions {
+	return &ClientBulkWriteOptions{}
+}
+
+// SetComment sets the value for the Comment field.
+func (c *ClientBulkWriteOptions) SetComment(comment interface{}) *ClientBulkWriteOptions {
+	c.Comment = comment
+	return c
+}
+
+// SetOrdered sets the value for the Ordered field.
+func (c *ClientBulkWriteOptions) SetOrdered(ordered bool) *ClientBulkWriteOptions {
+	c.Ordered = &ordered
+	return c
+}
+
+// SetBypassDocumentValidation sets the value for the BypassDocumentValidation field.
+func (c *ClientBulkWriteOptions) SetBypassDocumentValidation(bypass bool) *ClientBulkWriteOptions {
+	c.BypassDocumentValidation = &bypass
+	return c
+}
+
+// SetLet sets the value for the Let field. Let specifies parameters for all update and delete writes in the operation.
+// This must be a document mapping parameter names to values. Values must be constant or closed expressions that do
+// not reference document fields. Parameters can then be accessed as variables in an aggregate expression context
+// (e.g. "$$var").
+func (c *ClientBulkWriteOptions) SetLet(let interface{}) *ClientBulkWriteOptions {
+	c.Let = let
+	return c
+}
+
+// SetWriteConcern sets the value for the WriteConcern field.
+func (c *ClientBulkWriteOptions) SetWriteConcern(wc *writeconcern.WriteConcern) *ClientBulkWriteOptions {
+	c.WriteConcern = wc
+	return c
+}
+
+// SetVerboseResults sets the value for the VerboseResults field.
+func (c *ClientBulkWriteOptions) SetVerboseResults(verboseResults bool) *ClientBulkWriteOptions {
+	c.VerboseResults = &verboseResults
+	return c
+}
+
+// MergeClientBulkWriteOptions combines the given ClientBulkWriteOptions instances into a single
+// ClientBulkWriteOptions in a last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeClientBulkWriteOptions(opts ...*ClientBulkWriteOptions) *ClientBulkWriteOptions {
+	c := ClientBulkWrite()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Comment != nil {
+			c.Comment = opt.Comment
+		}
+		if opt.Ordered != nil {
+			c.Ordered = opt.Ordered
+		}
+		if opt.BypassDocumentValidation != nil {
+			c.BypassDocumentValidation = opt.BypassDocumentValidation
+		}
+		if opt.Let != nil {
+			c.Let = opt.Let
+		}
+		if opt.WriteConcern != nil {
+			c.WriteConcern = opt.WriteConcern
+		}
+		if opt.VerboseResults != nil {
+			c.VerboseResults = opt.VerboseResults
+		}
+	}
+
+	return c
+}
@@ -209,6 +209,7 @@ type ClientOptions struct {
 	HeartbeatInterval        *time.Duration
 	Hosts                    []string
 	HTTPClient               *http.Client
+	Interceptors             []event.CommandInterceptor
 	LoadBalanced             *bool
 	LocalThreshold           *time.Duration
 	LoggerOptions            *LoggerOptions
@@ -233,6 +234,7 @@ type ClientOptions struct {
 	SRVServiceName           *string
 	Timeout                  *time.Duration
 	TLSConfig                *tls.Config
+	TransactionMonitor       *event.TransactionMonitor
 	WriteConcern             *writeconcern.WriteConcern
 	ZlibLevel                *int
 	ZstdLevel                *int
@@ -745,6 +747,21 @@ func (c *ClientOptions) SetMonitor(m *event.CommandMonitor) *ClientOptions {
 	return c
 }
 
+// SetInterceptors specifies the interceptors that wrap the execution of every operation run by the Client. The
+// interceptors are called in order, so the first one is the outermost. See the event.CommandInterceptor documentation
+// for more information about what an interceptor can do.
+func (c *ClientOptions) SetInterceptors(interceptors ...event.CommandInterceptor) *ClientOptions {
+	c.Interceptors = interceptors
+	return c
+}
+
+// SetTransactionMonitor specifies a TransactionMonitor to receive the retry events of Session.WithTransaction. See the
+// event.TransactionMonitor documentation for more information about the events that can be received.
+func (c *ClientOptions) SetTransactionMonitor(m *event.TransactionMonitor) *ClientOptions {
+	c.TransactionMonitor = m
+	return c
+}
+
 // SetServerMonitor specifies an SDAM monitor used to monitor SDAM events.
 func (c *ClientOptions) SetServerMonitor(m *event.ServerMonitor) *ClientOptions {
 	c.ServerMonitor = m
@@ -1067,12 +1084,18 @@ func MergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.Monitor != nil {
 			c.Monitor = opt.Monitor
 		}
+		if opt.Interceptors != nil {
+			c.Interceptors = opt.Interceptors
+		}
 		if opt.ServerAPIOptions != nil {
 			c.ServerAPIOptions = opt.ServerAPIOptions
 		}
 		if opt.ServerMonitor != nil {
 			c.ServerMonitor = opt.ServerMonitor
 		}
+		if opt.TransactionMonitor != nil {
+			c.TransactionMonitor = opt.TransactionMonitor
+		}
 		if opt.ReadConcern != nil {
 			c.ReadConcern = opt.ReadConcern
 		}
@@ -0,0 +1,73 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// ExplainVerbosity specifies the verbosity mode of an explain command.
+type ExplainVerbosity string
+
+// These constants are the valid verbosity modes for an explain command. See
+// https://www.mongodb.com/docs/manual/reference/command/explain/#verbosity-modes for more information.
+const (
+	// QueryPlanner runs the query optimizer to choose the winning plan for the operation and returns the
+	// queryPlanner information without executing the plan.
+	QueryPlanner ExplainVerbosity = "queryPlanner"
+	// ExecutionStats chooses and executes the winning plan and returns statistics describing its execution.
+	ExecutionStats ExplainVerbosity = "executionStats"
+	// AllPlansExecution chooses and executes the winning plan and also returns partial execution statistics for the
+	// candidate plans considered during plan selection.
+	AllPlansExecution ExplainVerbosity = "allPlansExecution"
+)
+
+// ExplainOptions represents options that can be used to configure an explain command.
+type ExplainOptions struct {
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation. The default is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+
+	// The verbosity mode of the explain command. The default value is nil, which means the server default of
+	// AllPlansExecution will be used.
+	Verbosity *ExplainVerbosity
+}
+
+// Explain creates a new ExplainOptions instance.
+func Explain() *ExplainOptions {
+	return &ExplainOptions{}
+}
+
+// SetComment sets the value for the Comment field.
+func (eo *ExplainOptions) SetComment(comment interface{}) *ExplainOptions {
+	eo.Comment = comment
+	return eo
+}
+
+// SetVerbosity sets the value for the Verbosity field.
+func (eo *ExplainOptions) SetVerbosity(verbosity ExplainVerbosity) *ExplainOptions {
+	eo.Verbosity = &verbosity
+	return eo
+}
+
+// MergeExplainOptions combines the given ExplainOptions instances into a single ExplainOptions in a last-one-wins
+// fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeExplainOptions(opts ...*ExplainOptions) *ExplainOptions {
+	eo := Explain()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Comment != nil {
+			eo.Comment = opt.Comment
+		}
+		if opt.Verbosity != nil {
+			eo.Verbosity = opt.Verbosity
+		}
+	}
+
+	return eo
+}
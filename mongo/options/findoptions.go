@@ -79,6 +79,12 @@ type FindOptions struct {
 	// set.
 	OplogReplay *bool
 
+	// PrefetchBatches is the maximum number of batches that are fetched with getMore commands in the background while
+	// the caller processes the current batch. Prefetching only applies to cursors whose operation does not use an
+	// explicit session. The default value is 0, which means that the next batch is only fetched once the current batch
+	// is exhausted.
+	PrefetchBatches *int32
+
 	// Project is a document describing which fields will be included in the documents returned by the Find operation. The
 	// default value is nil, which means all fields will be included.
 	Projection interface{}
@@ -212,6 +218,12 @@ func (f *FindOptions) SetOplogReplay(b bool) *FindOptions {
 	return f
 }
 
+// SetPrefetchBatches sets the value for the PrefetchBatches field.
+func (f *FindOptions) SetPrefetchBatches(i int32) *FindOptions {
+	f.PrefetchBatches = &i
+	return f
+}
+
 // SetProjection sets the value for the Projection field.
 func (f *FindOptions) SetProjection(projection interface{}) *FindOptions {
 	f.Projection = projection
@@ -305,6 +317,9 @@ func MergeFindOptions(opts ...*FindOptions) *FindOptions {
 		if opt.OplogReplay != nil {
 			fo.OplogReplay = opt.OplogReplay
 		}
+		if opt.PrefetchBatches != nil {
+			fo.PrefetchBatches = opt.PrefetchBatches
+		}
 		if opt.Projection != nil {
 			fo.Projection = opt.Projection
 		}
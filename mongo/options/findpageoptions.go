@@ -0,0 +1,128 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// FindPageOptions represents options that can be used to configure a Collection.FindPage operation.
+type FindPageOptions struct {
+	// Specifies a collation to use for string comparisons during the operation. This option is only valid for MongoDB
+	// versions >= 3.4. For previous server versions, the driver will return an error if this option is used. The
+	// default value is nil, which means the default collation of the collection will be used.
+	Collation *Collation
+
+	// A string that will be included in server logs, profiling logs, and currentOp queries to help trace the operation.
+	// The default is nil, which means that no comment will be included in the logs.
+	Comment *string
+
+	// The index to use for the operation. This should either be the index name as a string or the index specification
+	// as a document. The driver will return an error if the hint parameter is a multi-key map. The default value is nil,
+	// which means that no hint will be sent.
+	Hint interface{}
+
+	// The maximum number of documents in a page. The default value is 20.
+	PageSize *int64
+
+	// A document describing which fields will be included in the documents of a page. The projection must include
+	// every field of the sort. The default value is nil, which means all fields will be included.
+	Projection interface{}
+
+	// A document specifying the order of the pages. The document must be ordered, its keys are field names and its
+	// values are 1 for ascending order or -1 for descending order. If the sort does not contain the _id field, _id is
+	// added as the last key in ascending order so that the order is total. The sort fields should not contain arrays,
+	// nulls or values of mixed types, because such values cannot be compared with range queries. The default value
+	// is nil, which means that documents are ordered by _id.
+	Sort interface{}
+
+	// The key used to sign page tokens with HMAC-SHA256. A token is rejected if it was signed with a different key, so
+	// tokens cannot be forged by clients that do not know the key. The key must be shared by all the processes that
+	// serve the same pages and should be at least 32 random bytes. This option is required, and FindPage returns an
+	// error if it is not set.
+	SigningKey []byte
+}
+
+// FindPage creates a new FindPageOptions instance.
+func FindPage() *FindPageOptions {
+	return &FindPageOptions{}
+}
+
+// SetCollation sets the value for the Collation field.
+func (fp *FindPageOptions) SetCollation(collation *Collation) *FindPageOptions {
+	fp.Collation = collation
+	return fp
+}
+
+// SetComment sets the value for the Comment field.
+func (fp *FindPageOptions) SetComment(comment string) *FindPageOptions {
+	fp.Comment = &comment
+	return fp
+}
+
+// SetHint sets the value for the Hint field.
+func (fp *FindPageOptions) SetHint(hint interface{}) *FindPageOptions {
+	fp.Hint = hint
+	return fp
+}
+
+// SetPageSize sets the value for the PageSize field.
+func (fp *FindPageOptions) SetPageSize(size int64) *FindPageOptions {
+	fp.PageSize = &size
+	return fp
+}
+
+// SetProjection sets the value for the Projection field.
+func (fp *FindPageOptions) SetProjection(projection interface{}) *FindPageOptions {
+	fp.Projection = projection
+	return fp
+}
+
+// SetSort sets the value for the Sort field.
+func (fp *FindPageOptions) SetSort(sort interface{}) *FindPageOptions {
+	fp.Sort = sort
+	return fp
+}
+
+// SetSigningKey sets the value for the SigningKey field.
+func (fp *FindPageOptions) SetSigningKey(key []byte) *FindPageOptions {
+	fp.SigningKey = key
+	return fp
+}
+
+// MergeFindPageOptions combines the given FindPageOptions instances into a single FindPageOptions in a last-one-wins
+// fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeFindPageOptions(opts ...*FindPageOptions) *FindPageOptions {
+	fp := FindPage()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Collation != nil {
+			fp.Collation = opt.Collation
+		}
+		if opt.Comment != nil {
+			fp.Comment = opt.Comment
+		}
+		if opt.Hint != nil {
+			fp.Hint = opt.Hint
+		}
+		if opt.PageSize != nil {
+			fp.PageSize = opt.PageSize
+		}
+		if opt.Projection != nil {
+			fp.Projection = opt.Projection
+		}
+		if opt.Sort != nil {
+			fp.Sort = opt.Sort
+		}
+		if opt.SigningKey != nil {
+			fp.SigningKey = opt.SigningKey
+		}
+	}
+
+	return fp
+}
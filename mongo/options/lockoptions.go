@@ -0,0 +1,83 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import "time"
+
+// LockerOptions represents options that can be used to configure a lock.Locker.
+type LockerOptions struct {
+	// A description of the process that holds the leases, which is stored in the lock documents to help diagnose
+	// which process holds a lock. It does not need to be unique. The default value is "<hostname>:<pid>".
+	Owner *string
+
+	// The amount of time that a lease is valid for after it is acquired or renewed. The default value is 30 seconds.
+	LeaseDuration *time.Duration
+
+	// The interval at which leases are renewed in the background. It must be shorter than LeaseDuration. The default
+	// value is a third of LeaseDuration.
+	RenewInterval *time.Duration
+
+	// The interval at which Locker.Acquire retries while the lock is held by another owner. The default value is 1
+	// second.
+	RetryInterval *time.Duration
+}
+
+// Locker creates a new LockerOptions instance.
+func Locker() *LockerOptions {
+	return &LockerOptions{}
+}
+
+// SetOwner sets the value for the Owner field.
+func (lo *LockerOptions) SetOwner(owner string) *LockerOptions {
+	lo.Owner = &owner
+	return lo
+}
+
+// SetLeaseDuration sets the value for the LeaseDuration field.
+func (lo *LockerOptions) SetLeaseDuration(d time.Duration) *LockerOptions {
+	lo.LeaseDuration = &d
+	return lo
+}
+
+// SetRenewInterval sets the value for the RenewInterval field.
+func (lo *LockerOptions) SetRenewInterval(d time.Duration) *LockerOptions {
+	lo.RenewInterval = &d
+	return lo
+}
+
+// SetRetryInterval sets the value for the RetryInterval field.
+func (lo *LockerOptions) SetRetryInterval(d time.Duration) *LockerOptions {
+	lo.RetryInterval = &d
+	return lo
+}
+
+// MergeLockerOptions combines the given LockerOptions instances into a single LockerOptions in a last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeLockerOptions(opts ...*LockerOptions) *LockerOptions {
+	lo := Locker()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Owner != nil {
+			lo.Owner = opt.Owner
+		}
+		if opt.LeaseDuration != nil {
+			lo.LeaseDuration = opt.LeaseDuration
+		}
+		if opt.RenewInterval != nil {
+			lo.RenewInterval = opt.RenewInterval
+		}
+		if opt.RetryInterval != nil {
+			lo.RetryInterval = opt.RetryInterval
+		}
+	}
+
+	return lo
+}
@@ -0,0 +1,122 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// MigratorOptions represents options that can be used to configure a migrate.Migrator.
+type MigratorOptions struct {
+	// The name of the collection, in the migrated database, that records the applied migrations. The default value is
+	// "migrations".
+	Collection *string
+
+	// The name of the collection, in the migrated database, that holds the lock serializing concurrent runners. The
+	// default value is the name of the migrations collection followed by "_lock".
+	LockCollection *string
+
+	// The options used for the lock that serializes concurrent runners (see the LockerOptions documentation). The
+	// default value is nil, which means that the default lock options are used.
+	LockerOptions *LockerOptions
+}
+
+// Migrator creates a new MigratorOptions instance.
+func Migrator() *MigratorOptions {
+	return &MigratorOptions{}
+}
+
+// SetCollection sets the value for the Collection field.
+func (mo *MigratorOptions) SetCollection(name string) *MigratorOptions {
+	mo.Collection = &name
+	return mo
+}
+
+// SetLockCollection sets the value for the LockCollection field.
+func (mo *MigratorOptions) SetLockCollection(name string) *MigratorOptions {
+	mo.LockCollection = &name
+	return mo
+}
+
+// SetLockerOptions sets the value for the LockerOptions field.
+func (mo *MigratorOptions) SetLockerOptions(lo *LockerOptions) *MigratorOptions {
+	mo.LockerOptions = lo
+	return mo
+}
+
+// MergeMigratorOptions combines the given MigratorOptions instances into a single MigratorOptions in a last-one-wins
+// fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeMigratorOptions(opts ...*MigratorOptions) *MigratorOptions {
+	mo := Migrator()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Collection != nil {
+			mo.Collection = opt.Collection
+		}
+		if opt.LockCollection != nil {
+			mo.LockCollection = opt.LockCollection
+		}
+		if opt.LockerOptions != nil {
+			mo.LockerOptions = opt.LockerOptions
+		}
+	}
+
+	return mo
+}
+
+// MigrateOptions represents options that can be used to configure a migrate.Migrator.Up or migrate.Migrator.Down
+// operation.
+type MigrateOptions struct {
+	// The version to migrate to. For Up, migrations with a higher version are not applied, and the default value is the
+	// highest registered version. For Down, migrations with this version or a lower one are not rolled back, and the
+	// default value is the version preceding the last applied migration, so that only that migration is rolled back.
+	Target *int64
+
+	// If true, the operation returns the migrations that it would run without running them or acquiring the lock. The
+	// default value is false.
+	DryRun *bool
+}
+
+// Migrate creates a new MigrateOptions instance.
+func Migrate() *MigrateOptions {
+	return &MigrateOptions{}
+}
+
+// SetTarget sets the value for the Target field.
+func (mo *MigrateOptions) SetTarget(version int64) *MigrateOptions {
+	mo.Target = &version
+	return mo
+}
+
+// SetDryRun sets the value for the DryRun field.
+func (mo *MigrateOptions) SetDryRun(b bool) *MigrateOptions {
+	mo.DryRun = &b
+	return mo
+}
+
+// MergeMigrateOptions combines the given MigrateOptions instances into a single MigrateOptions in a last-one-wins
+// fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeMigrateOptions(opts ...*MigrateOptions) *MigrateOptions {
+	mo := Migrate()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Target != nil {
+			mo.Target = opt.Target
+		}
+		if opt.DryRun != nil {
+			mo.DryRun = opt.DryRun
+		}
+	}
+
+	return mo
+}
@@ -0,0 +1,205 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// ModifyIndexOptions specifies the changes to make to an existing index as part of a Modify operation. The index is
+// identified by either its name or its key specification.
+type ModifyIndexOptions struct {
+	// The name of the index to modify. Exactly one of Name and Keys must be set.
+	Name *string
+
+	// The key specification document of the index to modify. Exactly one of Name and Keys must be set.
+	Keys interface{}
+
+	// Specifies whether the index should be hidden from the query planner. The default value is nil, which means that
+	// the visibility of the index is not changed.
+	Hidden *bool
+
+	// The new number of seconds after which documents in a TTL index expire. The index must already be a TTL index.
+	// The default value is nil, which means that the expiration is not changed.
+	ExpireAfterSeconds *int64
+
+	// If true, the index will be converted to a unique index. The PrepareUnique option must have been set to true in a
+	// previous Modify operation and the collection must not contain duplicate values for the index key. The default
+	// value is nil. This option is only valid for MongoDB versions >= 6.0.
+	Unique *bool
+
+	// If true, new inserts and updates that would create duplicate index keys will be rejected in preparation for
+	// converting the index to a unique index. The default value is nil. This option is only valid for MongoDB
+	// versions >= 6.0.
+	PrepareUnique *bool
+}
+
+// ModifyIndex creates a new ModifyIndexOptions instance.
+func ModifyIndex() *ModifyIndexOptions {
+	return &ModifyIndexOptions{}
+}
+
+// SetName sets the value for the Name field.
+func (mio *ModifyIndexOptions) SetName(name string) *ModifyIndexOptions {
+	mio.Name = &name
+	return mio
+}
+
+// SetKeys sets the value for the Keys field.
+func (mio *ModifyIndexOptions) SetKeys(keys interface{}) *ModifyIndexOptions {
+	mio.Keys = keys
+	return mio
+}
+
+// SetHidden sets the value for the Hidden field.
+func (mio *ModifyIndexOptions) SetHidden(hidden bool) *ModifyIndexOptions {
+	mio.Hidden = &hidden
+	return mio
+}
+
+// SetExpireAfterSeconds sets the value for the ExpireAfterSeconds field.
+func (mio *ModifyIndexOptions) SetExpireAfterSeconds(seconds int64) *ModifyIndexOptions {
+	mio.ExpireAfterSeconds = &seconds
+	return mio
+}
+
+// SetUnique sets the value for the Unique field.
+func (mio *ModifyIndexOptions) SetUnique(unique bool) *ModifyIndexOptions {
+	mio.Unique = &unique
+	return mio
+}
+
+// SetPrepareUnique sets the value for the PrepareUnique field.
+func (mio *ModifyIndexOptions) SetPrepareUnique(prepareUnique bool) *ModifyIndexOptions {
+	mio.PrepareUnique = &prepareUnique
+	return mio
+}
+
+// ModifyCollectionOptions represents options that can be used to configure a Modify operation. Only the options that
+// are set are changed on the collection.
+type ModifyCollectionOptions struct {
+	// A document specifying the new validation rules for the collection. See
+	// https://www.mongodb.com/docs/manual/core/schema-validation/ for more information about schema validation. The
+	// default value is nil, which means that the validator is not changed.
+	Validator interface{}
+
+	// Specifies how strictly the server applies validation rules to existing documents in the collection during update
+	// operations. Valid values are "off", "strict", and "moderate". The default value is nil, which means that the
+	// validation level is not changed.
+	ValidationLevel *string
+
+	// Specifies what should happen if a document being inserted does not pass validation. Valid values are "error" and
+	// "warn". The default value is nil, which means that the validation action is not changed.
+	ValidationAction *string
+
+	// Specifies how change streams opened against the collection can return pre- and post-images of updated
+	// documents. The value must be a document in the form {<option name>: <options>}. This option is only valid for
+	// MongoDB versions >= 6.0. The default value is nil, which means that the setting is not changed.
+	ChangeStreamPreAndPostImages interface{}
+
+	// The changes to make to an existing index. The default value is nil, which means that no index is modified.
+	Index *ModifyIndexOptions
+
+	// The new number of seconds after which old time-series data or documents in a clustered collection should be
+	// deleted. This option is only valid for MongoDB versions >= 5.0. The default value is nil, which means that the
+	// expiration is not changed.
+	ExpireAfterSeconds *int64
+
+	// The name of the source collection or view for a view. This must be set together with Pipeline when modifying a
+	// view. The default value is nil.
+	ViewOn *string
+
+	// The new aggregation pipeline for a view. This must be set together with ViewOn when modifying a view. The
+	// default value is nil.
+	Pipeline interface{}
+}
+
+// ModifyCollection creates a new ModifyCollectionOptions instance.
+func ModifyCollection() *ModifyCollectionOptions {
+	return &ModifyCollectionOptions{}
+}
+
+// SetValidator sets the value for the Validator field.
+func (mco *ModifyCollectionOptions) SetValidator(validator interface{}) *ModifyCollectionOptions {
+	mco.Validator = validator
+	return mco
+}
+
+// SetValidationLevel sets the value for the ValidationLevel field.
+func (mco *ModifyCollectionOptions) SetValidationLevel(level string) *ModifyCollectionOptions {
+	mco.ValidationLevel = &level
+	return mco
+}
+
+// SetValidationAction sets the value for the ValidationAction field.
+func (mco *ModifyCollectionOptions) SetValidationAction(action string) *ModifyCollectionOptions {
+	mco.ValidationAction = &action
+	return mco
+}
+
+// SetChangeStreamPreAndPostImages sets the value for the ChangeStreamPreAndPostImages field.
+func (mco *ModifyCollectionOptions) SetChangeStreamPreAndPostImages(csppi interface{}) *ModifyCollectionOptions {
+	mco.ChangeStreamPreAndPostImages = csppi
+	return mco
+}
+
+// SetIndex sets the value for the Index field.
+func (mco *ModifyCollectionOptions) SetIndex(index *ModifyIndexOptions) *ModifyCollectionOptions {
+	mco.Index = index
+	return mco
+}
+
+// SetExpireAfterSeconds sets the value for the ExpireAfterSeconds field.
+func (mco *ModifyCollectionOptions) SetExpireAfterSeconds(seconds int64) *ModifyCollectionOptions {
+	mco.ExpireAfterSeconds = &seconds
+	return mco
+}
+
+// SetView sets the values for the ViewOn and Pipeline fields.
+func (mco *ModifyCollectionOptions) SetView(viewOn string, pipeline interface{}) *ModifyCollectionOptions {
+	mco.ViewOn = &viewOn
+	mco.Pipeline = pipeline
+	return mco
+}
+
+// MergeModifyCollectionOptions combines the given ModifyCollectionOptions instances into a single
+// ModifyCollectionOptions in a last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeModifyCollectionOptions(opts ...*ModifyCollectionOptions) *ModifyCollectionOptions {
+	mco := ModifyCollection()
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+
+		if opt.Validator != nil {
+			mco.Validator = opt.Validator
+		}
+		if opt.ValidationLevel != nil {
+			mco.ValidationLevel = opt.ValidationLevel
+		}
+		if opt.ValidationAction != nil {
+			mco.ValidationAction = opt.ValidationAction
+		}
+		if opt.ChangeStreamPreAndPostImages != nil {
+			mco.ChangeStreamPreAndPostImages = opt.ChangeStreamPreAndPostImages
+		}
+		if opt.Index != nil {
+			mco.Index = opt.Index
+		}
+		if opt.ExpireAfterSeconds != nil {
+			mco.ExpireAfterSeconds = opt.ExpireAfterSeconds
+		}
+		if opt.ViewOn != nil {
+			mco.ViewOn = opt.ViewOn
+		}
+		if opt.Pipeline != nil {
+			mco.Pipeline = opt.Pipeline
+		}
+	}
+
+	return mco
+}
@@ -0,0 +1,83 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// ParallelScanOptions represents options that can be used to configure a Collection.ParallelScan operation.
+type ParallelScanOptions struct {
+	// The field whose values are split into ranges. The field should be indexed and should not contain arrays. The
+	// default value is "_id".
+	Field *string
+
+	// A filter that selects the documents to scan. The default value is nil, which means that all documents in the
+	// collection are scanned.
+	Filter interface{}
+
+	// The number of documents sampled with $sample to compute the range boundaries. Larger samples give ranges with a
+	// more even number of documents. The default value is 100 times the number of partitions.
+	SampleSize *int32
+
+	// Options used for the find command of each partition. The Collation is also used to compute the range
+	// boundaries. The Sort, Skip and Limit options apply to each partition separately. The default value is nil.
+	FindOptions *FindOptions
+}
+
+// ParallelScan creates a new ParallelScanOptions instance.
+func ParallelScan() *ParallelScanOptions {
+	return &ParallelScanOptions{}
+}
+
+// SetField sets the value for the Field field.
+func (pso *ParallelScanOptions) SetField(field string) *ParallelScanOptions {
+	pso.Field = &field
+	return pso
+}
+
+// SetFilter sets the value for the Filter field.
+func (pso *ParallelScanOptions) SetFilter(filter interface{}) *ParallelScanOptions {
+	pso.Filter = filter
+	return pso
+}
+
+// SetSampleSize sets the value for the SampleSize field.
+func (pso *ParallelScanOptions) SetSampleSize(size int32) *ParallelScanOptions {
+	pso.SampleSize = &size
+	return pso
+}
+
+// SetFindOptions sets the value for the FindOptions field.
+func (pso *ParallelScanOptions) SetFindOptions(opts *FindOptions) *ParallelScanOptions {
+	pso.FindOptions = opts
+	return pso
+}
+
+// MergeParallelScanOptions combines the given ParallelScanOptions instances into a single ParallelScanOptions in a
+// last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeParallelScanOptions(opts ...*ParallelScanOptions) *ParallelScanOptions {
+	pso := ParallelScan()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Field != nil {
+			pso.Field = opt.Field
+		}
+		if opt.Filter != nil {
+			pso.Filter = opt.Filter
+		}
+		if opt.SampleSize != nil {
+			pso.SampleSize = opt.SampleSize
+		}
+		if opt.FindOptions != nil {
+			pso.FindOptions = opt.FindOptions
+		}
+	}
+
+	return pso
+}
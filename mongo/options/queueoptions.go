@@ -0,0 +1,148 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+import "time"
+
+// QueueOptions represents options that can be used to configure a queue.Queue.
+type QueueOptions struct {
+	// The amount of time that a dequeued job is hidden from other workers. If the job is not acknowledged within that
+	// time, it is delivered again. The default value is 30 seconds.
+	VisibilityTimeout *time.Duration
+
+	// The maximum number of times a job is delivered. A job that fails or times out on its last attempt is moved to
+	// the dead letter collection. The default value is 5.
+	MaxAttempts *int32
+
+	// The name of the collection, in the same database as the queue, to which jobs are moved once they have exhausted
+	// their attempts. The default value is the name of the queue collection followed by "_dead".
+	DeadLetterCollection *string
+
+	// The amount of time that a job that has been negatively acknowledged waits before it is delivered again. The
+	// default value is 0, which means that it is delivered again immediately.
+	RetryDelay *time.Duration
+
+	// The maximum amount of time that an idle Dequeue waits before checking the queue again. Idle workers are woken up
+	// by a change stream when jobs are enqueued, so this only matters if change streams are not available. The default
+	// value is 10 seconds.
+	PollInterval *time.Duration
+}
+
+// Queue creates a new QueueOptions instance.
+func Queue() *QueueOptions {
+	return &QueueOptions{}
+}
+
+// SetVisibilityTimeout sets the value for the VisibilityTimeout field.
+func (qo *QueueOptions) SetVisibilityTimeout(d time.Duration) *QueueOptions {
+	qo.VisibilityTimeout = &d
+	return qo
+}
+
+// SetMaxAttempts sets the value for the MaxAttempts field.
+func (qo *QueueOptions) SetMaxAttempts(n int32) *QueueOptions {
+	qo.MaxAttempts = &n
+	return qo
+}
+
+// SetDeadLetterCollection sets the value for the DeadLetterCollection field.
+func (qo *QueueOptions) SetDeadLetterCollection(name string) *QueueOptions {
+	qo.DeadLetterCollection = &name
+	return qo
+}
+
+// SetRetryDelay sets the value for the RetryDelay field.
+func (qo *QueueOptions) SetRetryDelay(d time.Duration) *QueueOptions {
+	qo.RetryDelay = &d
+	return qo
+}
+
+// SetPollInterval sets the value for the PollInterval field.
+func (qo *QueueOptions) SetPollInterval(d time.Duration) *QueueOptions {
+	qo.PollInterval = &d
+	return qo
+}
+
+// MergeQueueOptions combines the given QueueOptions instances into a single QueueOptions in a last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeQueueOptions(opts ...*QueueOptions) *QueueOptions {
+	qo := Queue()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.VisibilityTimeout != nil {
+			qo.VisibilityTimeout = opt.VisibilityTimeout
+		}
+		if opt.MaxAttempts != nil {
+			qo.MaxAttempts = opt.MaxAttempts
+		}
+		if opt.DeadLetterCollection != nil {
+			qo.DeadLetterCollection = opt.DeadLetterCollection
+		}
+		if opt.RetryDelay != nil {
+			qo.RetryDelay = opt.RetryDelay
+		}
+		if opt.PollInterval != nil {
+			qo.PollInterval = opt.PollInterval
+		}
+	}
+
+	return qo
+}
+
+// EnqueueOptions represents options that can be used to configure a queue.Queue.Enqueue operation.
+type EnqueueOptions struct {
+	// The priority of the job. Jobs with a higher priority are dequeued first, and jobs with the same priority are
+	// dequeued in the order in which they become visible. The default value is 0.
+	Priority *int32
+
+	// The amount of time before the job can be dequeued. The default value is 0, which means that the job can be
+	// dequeued immediately.
+	Delay *time.Duration
+}
+
+// Enqueue creates a new EnqueueOptions instance.
+func Enqueue() *EnqueueOptions {
+	return &EnqueueOptions{}
+}
+
+// SetPriority sets the value for the Priority field.
+func (eo *EnqueueOptions) SetPriority(p int32) *EnqueueOptions {
+	eo.Priority = &p
+	return eo
+}
+
+// SetDelay sets the value for the Delay field.
+func (eo *EnqueueOptions) SetDelay(d time.Duration) *EnqueueOptions {
+	eo.Delay = &d
+	return eo
+}
+
+// MergeEnqueueOptions combines the given EnqueueOptions instances into a single EnqueueOptions in a last-one-wins
+// fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeEnqueueOptions(opts ...*EnqueueOptions) *EnqueueOptions {
+	eo := Enqueue()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.Priority != nil {
+			eo.Priority = opt.Priority
+		}
+		if opt.Delay != nil {
+			eo.Delay = opt.Delay
+		}
+	}
+
+	return eo
+}
@@ -0,0 +1,58 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// RenameCollectionOptions represents options that can be used to configure a RenameCollection operation.
+type RenameCollectionOptions struct {
+	// If true, an existing collection with the target name will be dropped before the source collection is renamed.
+	// The default value is false, which means that the operation will fail with mongo.ErrNamespaceExists if the target
+	// collection exists.
+	DropTarget *bool
+
+	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
+	// the operation.  The default value is nil, which means that no comment will be included in the logs.
+	Comment interface{}
+}
+
+// RenameCollection creates a new RenameCollectionOptions instance.
+func RenameCollection() *RenameCollectionOptions {
+	return &RenameCollectionOptions{}
+}
+
+// SetDropTarget sets the value for the DropTarget field.
+func (rco *RenameCollectionOptions) SetDropTarget(dropTarget bool) *RenameCollectionOptions {
+	rco.DropTarget = &dropTarget
+	return rco
+}
+
+// SetComment sets the value for the Comment field.
+func (rco *RenameCollectionOptions) SetComment(comment interface{}) *RenameCollectionOptions {
+	rco.Comment = comment
+	return rco
+}
+
+// MergeRenameCollectionOptions combines the given RenameCollectionOptions instances into a single
+// RenameCollectionOptions in a last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeRenameCollectionOptions(opts ...*RenameCollectionOptions) *RenameCollectionOptions {
+	rco := RenameCollection()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.DropTarget != nil {
+			rco.DropTarget = opt.DropTarget
+		}
+		if opt.Comment != nil {
+			rco.Comment = opt.Comment
+		}
+	}
+
+	return rco
+}
@@ -0,0 +1,82 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// SyncIndexesOptions represents options that can be used to configure an IndexView.Sync operation.
+type SyncIndexesOptions struct {
+	// If true, the plan is computed and returned without being applied. The default value is false.
+	DryRun *bool
+
+	// If true, existing indexes that are not in the desired set are dropped. The _id index is never dropped. The
+	// default value is false, which means that such indexes are left in place and not included in the plan.
+	DropUnlisted *bool
+
+	// If true, existing indexes whose key pattern or name matches a desired index but whose options differ are
+	// dropped and recreated with the desired options. The default value is false, which means that applying a plan
+	// with conflicts fails without making any changes.
+	ReplaceConflicting *bool
+
+	// Options used for the createIndexes command that creates the missing indexes. The default value is nil.
+	CreateIndexesOptions *CreateIndexesOptions
+}
+
+// SyncIndexes creates a new SyncIndexesOptions instance.
+func SyncIndexes() *SyncIndexesOptions {
+	return &SyncIndexesOptions{}
+}
+
+// SetDryRun sets the value for the DryRun field.
+func (sio *SyncIndexesOptions) SetDryRun(dryRun bool) *SyncIndexesOptions {
+	sio.DryRun = &dryRun
+	return sio
+}
+
+// SetDropUnlisted sets the value for the DropUnlisted field.
+func (sio *SyncIndexesOptions) SetDropUnlisted(dropUnlisted bool) *SyncIndexesOptions {
+	sio.DropUnlisted = &dropUnlisted
+	return sio
+}
+
+// SetReplaceConflicting sets the value for the ReplaceConflicting field.
+func (sio *SyncIndexesOptions) SetReplaceConflicting(replaceConflicting bool) *SyncIndexesOptions {
+	sio.ReplaceConflicting = &replaceConflicting
+	return sio
+}
+
+// SetCreateIndexesOptions sets the value for the CreateIndexesOptions field.
+func (sio *SyncIndexesOptions) SetCreateIndexesOptions(opts *CreateIndexesOptions) *SyncIndexesOptions {
+	sio.CreateIndexesOptions = opts
+	return sio
+}
+
+// MergeSyncIndexesOptions combines the given SyncIndexesOptions instances into a single SyncIndexesOptions in a
+// last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeSyncIndexesOptions(opts ...*SyncIndexesOptions) *SyncIndexesOptions {
+	sio := SyncIndexes()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.DryRun != nil {
+			sio.DryRun = opt.DryRun
+		}
+		if opt.DropUnlisted != nil {
+			sio.DropUnlisted = opt.DropUnlisted
+		}
+		if opt.ReplaceConflicting != nil {
+			sio.ReplaceConflicting = opt.ReplaceConflicting
+		}
+		if opt.CreateIndexesOptions != nil {
+			sio.CreateIndexesOptions = opt.CreateIndexesOptions
+		}
+	}
+
+	return sio
+}
@@ -39,6 +39,29 @@ type TransactionOptions struct {
 	// be used in its place to control the amount of time that a single operation can run before returning an error.
 	// MaxCommitTime is ignored if Timeout is set on the client.
 	MaxCommitTime *time.Duration
+
+	// The maximum number of attempts made by Session.WithTransaction. Each run of the callback and each retry of the
+	// commit counts as an attempt. The default value is nil, which means that the number of attempts is only limited by
+	// MaxRetryTime.
+	MaxAttempts *int
+
+	// The maximum amount of time during which Session.WithTransaction retries the transaction, measured from the start
+	// of the first attempt. The default value is nil, which means that the transaction is retried for up to 120
+	// seconds.
+	MaxRetryTime *time.Duration
+
+	// The delay before the first retry of Session.WithTransaction. The delay doubles with each retry, up to
+	// MaxRetryBackoff, and a random jitter is applied so that the actual delay is between zero and the computed delay.
+	// The default value is nil, which means that the transaction is retried immediately.
+	RetryBackoff *time.Duration
+
+	// The maximum delay between retries of Session.WithTransaction before the jitter is applied. The default value is
+	// nil, which means that the delay is not limited.
+	MaxRetryBackoff *time.Duration
+
+	// A function called by Session.WithTransaction before each retry with the error that caused the retry and the
+	// number of the attempt that failed, starting at 1. The default value is nil.
+	OnRetry func(err error, attempt int)
 }
 
 // Transaction creates a new TransactionOptions instance.
@@ -74,6 +97,36 @@ func (t *TransactionOptions) SetMaxCommitTime(mct *time.Duration) *TransactionOp
 	return t
 }
 
+// SetMaxAttempts sets the value for the MaxAttempts field.
+func (t *TransactionOptions) SetMaxAttempts(attempts int) *TransactionOptions {
+	t.MaxAttempts = &attempts
+	return t
+}
+
+// SetMaxRetryTime sets the value for the MaxRetryTime field.
+func (t *TransactionOptions) SetMaxRetryTime(d time.Duration) *TransactionOptions {
+	t.MaxRetryTime = &d
+	return t
+}
+
+// SetRetryBackoff sets the value for the RetryBackoff field.
+func (t *TransactionOptions) SetRetryBackoff(d time.Duration) *TransactionOptions {
+	t.RetryBackoff = &d
+	return t
+}
+
+// SetMaxRetryBackoff sets the value for the MaxRetryBackoff field.
+func (t *TransactionOptions) SetMaxRetryBackoff(d time.Duration) *TransactionOptions {
+	t.MaxRetryBackoff = &d
+	return t
+}
+
+// SetOnRetry sets the value for the OnRetry field.
+func (t *TransactionOptions) SetOnRetry(fn func(err error, attempt int)) *TransactionOptions {
+	t.OnRetry = fn
+	return t
+}
+
 // MergeTransactionOptions combines the given TransactionOptions instances into a single TransactionOptions in a
 // last-one-wins fashion.
 //
@@ -97,6 +150,21 @@ func MergeTransactionOptions(opts ...*TransactionOptions) *TransactionOptions {
 		if opt.MaxCommitTime != nil {
 			t.MaxCommitTime = opt.MaxCommitTime
 		}
+		if opt.MaxAttempts != nil {
+			t.MaxAttempts = opt.MaxAttempts
+		}
+		if opt.MaxRetryTime != nil {
+			t.MaxRetryTime = opt.MaxRetryTime
+		}
+		if opt.RetryBackoff != nil {
+			t.RetryBackoff = opt.RetryBackoff
+		}
+		if opt.MaxRetryBackoff != nil {
+			t.MaxRetryBackoff = opt.MaxRetryBackoff
+		}
+		if opt.OnRetry != nil {
+			t.OnRetry = opt.OnRetry
+		}
 	}
 
 	return t
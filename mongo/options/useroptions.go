@@ -0,0 +1,143 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package options
+
+// UsersInfoOptions represents options that can be used to configure a UsersInfo operation.
+type UsersInfoOptions struct {
+	// If true, the returned users will include their credentials. The usersInfo command is always redacted in command
+	// monitoring events and logs. The default value is false.
+	ShowCredentials *bool
+
+	// If true, the returned users will include their custom data. The default value is nil, which means that the
+	// server default of true will be used.
+	ShowCustomData *bool
+
+	// If true, the returned users will include their inherited privileges. The default value is false.
+	ShowPrivileges *bool
+
+	// If true, the returned users will include their authentication restrictions. This option can only be used
+	// together with ShowPrivileges. The default value is false.
+	ShowAuthenticationRestrictions *bool
+}
+
+// UsersInfo creates a new UsersInfoOptions instance.
+func UsersInfo() *UsersInfoOptions {
+	return &UsersInfoOptions{}
+}
+
+// SetShowCredentials sets the value for the ShowCredentials field.
+func (uio *UsersInfoOptions) SetShowCredentials(show bool) *UsersInfoOptions {
+	uio.ShowCredentials = &show
+	return uio
+}
+
+// SetShowCustomData sets the value for the ShowCustomData field.
+func (uio *UsersInfoOptions) SetShowCustomData(show bool) *UsersInfoOptions {
+	uio.ShowCustomData = &show
+	return uio
+}
+
+// SetShowPrivileges sets the value for the ShowPrivileges field.
+func (uio *UsersInfoOptions) SetShowPrivileges(show bool) *UsersInfoOptions {
+	uio.ShowPrivileges = &show
+	return uio
+}
+
+// SetShowAuthenticationRestrictions sets the value for the ShowAuthenticationRestrictions field.
+func (uio *UsersInfoOptions) SetShowAuthenticationRestrictions(show bool) *UsersInfoOptions {
+	uio.ShowAuthenticationRestrictions = &show
+	return uio
+}
+
+// MergeUsersInfoOptions combines the given UsersInfoOptions instances into a single UsersInfoOptions in a
+// last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeUsersInfoOptions(opts ...*UsersInfoOptions) *UsersInfoOptions {
+	uio := UsersInfo()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.ShowCredentials != nil {
+			uio.ShowCredentials = opt.ShowCredentials
+		}
+		if opt.ShowCustomData != nil {
+			uio.ShowCustomData = opt.ShowCustomData
+		}
+		if opt.ShowPrivileges != nil {
+			uio.ShowPrivileges = opt.ShowPrivileges
+		}
+		if opt.ShowAuthenticationRestrictions != nil {
+			uio.ShowAuthenticationRestrictions = opt.ShowAuthenticationRestrictions
+		}
+	}
+
+	return uio
+}
+
+// RolesInfoOptions represents options that can be used to configure a RolesInfo operation.
+type RolesInfoOptions struct {
+	// If true, the returned roles will include their privileges. The default value is false.
+	ShowPrivileges *bool
+
+	// If true, the built-in roles of the database will be returned in addition to the user-defined roles when no role
+	// names are given. The default value is false.
+	ShowBuiltinRoles *bool
+
+	// If true, the returned roles will include their authentication restrictions. The default value is false.
+	ShowAuthenticationRestrictions *bool
+}
+
+// RolesInfo creates a new RolesInfoOptions instance.
+func RolesInfo() *RolesInfoOptions {
+	return &RolesInfoOptions{}
+}
+
+// SetShowPrivileges sets the value for the ShowPrivileges field.
+func (rio *RolesInfoOptions) SetShowPrivileges(show bool) *RolesInfoOptions {
+	rio.ShowPrivileges = &show
+	return rio
+}
+
+// SetShowBuiltinRoles sets the value for the ShowBuiltinRoles field.
+func (rio *RolesInfoOptions) SetShowBuiltinRoles(show bool) *RolesInfoOptions {
+	rio.ShowBuiltinRoles = &show
+	return rio
+}
+
+// SetShowAuthenticationRestrictions sets the value for the ShowAuthenticationRestrictions field.
+func (rio *RolesInfoOptions) SetShowAuthenticationRestrictions(show bool) *RolesInfoOptions {
+	rio.ShowAuthenticationRestrictions = &show
+	return rio
+}
+
+// MergeRolesInfoOptions combines the given RolesInfoOptions instances into a single RolesInfoOptions in a
+// last-one-wins fashion.
+//
+// Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
+// single options struct instead.
+func MergeRolesInfoOptions(opts ...*RolesInfoOptions) *RolesInfoOptions {
+	rio := RolesInfo()
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if opt.ShowPrivileges != nil {
+			rio.ShowPrivileges = opt.ShowPrivileges
+		}
+		if opt.ShowBuiltinRoles != nil {
+			rio.ShowBuiltinRoles = opt.ShowBuiltinRoles
+		}
+		if opt.ShowAuthenticationRestrictions != nil {
+			rio.ShowAuthenticationRestrictions = opt.ShowAuthenticationRestrictions
+		}
+	}
+
+	return rio
+}
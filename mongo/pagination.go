@@ -0,0 +1,284 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/bsoncodec"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+)
+
+// ErrInvalidPageToken is returned by FindPage if a page token is malformed, has been modified, or was created for a
+// different collection or sort order.
+var ErrInvalidPageToken = errors.New("mongo: invalid page token")
+
+// defaultPageSize is the number of documents in a page if FindPageOptions.PageSize is not set.
+const defaultPageSize = 20
+
+// pageTokenVersion is the version of the page token format.
+const pageTokenVersion = 1
+
+// Page is a page of documents returned by Collection.FindPage.
+type Page struct {
+	// Documents contains the documents of the page in sort order.
+	Documents []bson.Raw
+
+	// NextToken is the token to pass to FindPage to get the next page. It is empty if this is the last page.
+	NextToken string
+
+	bsonOpts *options.BSONOptions
+	registry *bsoncodec.Registry
+}
+
+// Decode decodes the documents of the page into results, which must be a pointer to a slice. The documents are
+// decoded with the registry and BSON options of the collection that returned the page.
+func (p *Page) Decode(results interface{}) error {
+	resultsVal := reflect.ValueOf(results)
+	if resultsVal.Kind() != reflect.Ptr || resultsVal.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("results argument must be a pointer to a slice, but was a %T", results)
+	}
+
+	sliceVal := reflect.MakeSlice(resultsVal.Elem().Type(), len(p.Documents), len(p.Documents))
+	for i, doc := range p.Documents {
+		dec, err := getDecoder(doc, p.bsonOpts, p.registry)
+		if err != nil {
+			return fmt.Errorf("error configuring BSON decoder: %w", err)
+		}
+		if err := dec.Decode(sliceVal.Index(i).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	resultsVal.Elem().Set(sliceVal)
+	return nil
+}
+
+// pageKey is a field of the sort order of a paginated query.
+type pageKey struct {
+	name string
+	desc bool
+}
+
+// FindPage executes a find command and returns one page of the documents in the collection that match filter. The
+// pages are ordered by the Sort option and are read with range queries on the sort fields instead of skipping
+// documents, so every page takes the same time to read and documents inserted or deleted between two calls do not
+// shift the following pages.
+//
+// The token parameter must be "" for the first page, and the NextToken of the previous page for the following ones.
+// The token encodes the sort field values of the last document of the previous page and is signed with the SigningKey
+// option, which is required. FindPage returns ErrInvalidPageToken if the token cannot be verified or was created for a
+// different collection or sort order. Passing a token together with a different filter is allowed and continues from
+// the same position.
+//
+// The filter parameter must be a document containing query operators and can be used to select which documents are
+// included in the result. It cannot be nil. An empty document (e.g. bson.D{}) should be used to include all documents.
+//
+// The opts parameter can be used to specify options for the operation (see the options.FindPageOptions
+// documentation).
+func (coll *Collection) FindPage(ctx context.Context, filter interface{}, token string,
+	opts ...*options.FindPageOptions) (*Page, error) {
+
+	po := options.MergeFindPageOptions(opts...)
+
+	if len(po.SigningKey) == 0 {
+		return nil, errors.New("a signing key must be set to sign page tokens")
+	}
+	pageSize := int64(defaultPageSize)
+	if po.PageSize != nil {
+		if *po.PageSize <= 0 {
+			return nil, fmt.Errorf("page size must be positive, but was %d", *po.PageSize)
+		}
+		pageSize = *po.PageSize
+	}
+	keys, sort, err := coll.pageSort(po.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := marshal(filter, coll.bsonOpts, coll.registry)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		values, err := coll.parsePageToken(token, sort, po.SigningKey, len(keys))
+		if err != nil {
+			return nil, err
+		}
+		f = keysetFilter(f, keys, values)
+	}
+
+	fo := options.Find().SetSort(bson.Raw(sort)).SetLimit(pageSize + 1)
+	if po.Collation != nil {
+		fo.SetCollation(po.Collation)
+	}
+	if po.Comment != nil {
+		fo.SetComment(*po.Comment)
+	}
+	if po.Hint != nil {
+		fo.SetHint(po.Hint)
+	}
+	if po.Projection != nil {
+		fo.SetProjection(po.Projection)
+	}
+	cursor, err := coll.Find(ctx, bson.Raw(f), fo)
+	if err != nil {
+		return nil, err
+	}
+	var docs []bson.Raw
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	page := &Page{Documents: docs, bsonOpts: coll.bsonOpts, registry: coll.registry}
+	if int64(len(docs)) > pageSize {
+		page.Documents = docs[:pageSize]
+		page.NextToken, err = coll.newPageToken(page.Documents[pageSize-1], keys, sort, po.SigningKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// pageSort returns the keys of the sort order described by sort and the sort document sent to the server, which ends
+// with the _id field.
+func (coll *Collection) pageSort(sort interface{}) ([]pageKey, bsoncore.Document, error) {
+	var elems []bsoncore.Element
+	if sort != nil {
+		if isUnorderedMap(sort) {
+			return nil, nil, ErrMapForOrderedArgument{"sort"}
+		}
+		doc, err := marshal(sort, coll.bsonOpts, coll.registry)
+		if err != nil {
+			return nil, nil, err
+		}
+		if elems, err = doc.Elements(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var keys []pageKey
+	hasID := false
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	for _, elem := range elems {
+		dir, ok := elem.Value().AsInt64OK()
+		if !ok || (dir != 1 && dir != -1) {
+			return nil, nil, fmt.Errorf("sort direction of field %q must be 1 or -1", elem.Key())
+		}
+		keys = append(keys, pageKey{name: elem.Key(), desc: dir == -1})
+		doc = bsoncore.AppendInt32Element(doc, elem.Key(), int32(dir))
+		hasID = hasID || elem.Key() == "_id"
+	}
+	if !hasID {
+		keys = append(keys, pageKey{name: "_id"})
+		doc = bsoncore.AppendInt32Element(doc, "_id", 1)
+	}
+	doc, err := bsoncore.AppendDocumentEnd(doc, idx)
+	return keys, doc, err
+}
+
+// keysetFilter returns a filter that matches the documents of filter that come after values in the sort order of keys.
+// For keys a, b and c it is {$or: [{a: {$gt: va}}, {a: va, b: {$gt: vb}}, {a: va, b: vb, c: {$gt: vc}}]}, with $lt
+// instead of $gt for descending keys.
+func keysetFilter(filter bsoncore.Document, keys []pageKey, values []bsoncore.Value) bsoncore.Document {
+	aidx, ranges := bsoncore.AppendArrayStart(nil)
+	for i, key := range keys {
+		var didx int32
+		didx, ranges = bsoncore.AppendDocumentElementStart(ranges, strconv.Itoa(i))
+		for j := 0; j < i; j++ {
+			ranges = bsoncore.AppendDocumentElement(ranges, keys[j].name,
+				bsoncore.NewDocumentBuilder().AppendValue("$eq", values[j]).Build())
+		}
+		op := "$gt"
+		if key.desc {
+			op = "$lt"
+		}
+		ranges = bsoncore.AppendDocumentElement(ranges, key.name,
+			bsoncore.NewDocumentBuilder().AppendValue(op, values[i]).Build())
+		ranges, _ = bsoncore.AppendDocumentEnd(ranges, didx)
+	}
+	ranges, _ = bsoncore.AppendArrayEnd(ranges, aidx)
+
+	keyset := bsoncore.NewDocumentBuilder().AppendArray("$or", ranges).Build()
+	if len(filter) <= 5 {
+		return keyset
+	}
+	return bsoncore.NewDocumentBuilder().
+		AppendArray("$and", bsoncore.NewArrayBuilder().AppendDocument(filter).AppendDocument(keyset).Build()).
+		Build()
+}
+
+// newPageToken returns a token that holds the values of the sort keys in doc.
+func (coll *Collection) newPageToken(doc bson.Raw, keys []pageKey, sort bsoncore.Document,
+	signingKey []byte) (string, error) {
+
+	aidx, values := bsoncore.AppendArrayStart(nil)
+	for i, key := range keys {
+		val, err := doc.LookupErr(strings.Split(key.name, ".")...)
+		if err != nil || val.Type == bson.TypeNull || val.Type == bson.TypeUndefined {
+			return "", fmt.Errorf("cannot create page token: sort field %q is missing or null in a document", key.name)
+		}
+		values = bsoncore.AppendValueElement(values, strconv.Itoa(i), bsoncore.Value{Type: val.Type, Data: val.Value})
+	}
+	values, _ = bsoncore.AppendArrayEnd(values, aidx)
+
+	payload := bsoncore.NewDocumentBuilder().
+		AppendInt32("v", pageTokenVersion).
+		AppendArray("k", values).
+		Build()
+	token := append([]byte(payload), coll.pageTokenMAC(payload, sort, signingKey)...)
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// parsePageToken verifies token and returns the sort key values it holds.
+func (coll *Collection) parsePageToken(token string, sort bsoncore.Document, signingKey []byte,
+	numKeys int) ([]bsoncore.Value, error) {
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(raw) < sha256.Size {
+		return nil, ErrInvalidPageToken
+	}
+	payload, mac := bsoncore.Document(raw[:len(raw)-sha256.Size]), raw[len(raw)-sha256.Size:]
+	if !hmac.Equal(mac, coll.pageTokenMAC(payload, sort, signingKey)) || payload.Validate() != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	version, ok := payload.Lookup("v").Int32OK()
+	if !ok || version != pageTokenVersion {
+		return nil, ErrInvalidPageToken
+	}
+	arr, ok := payload.Lookup("k").ArrayOK()
+	if !ok {
+		return nil, ErrInvalidPageToken
+	}
+	values, err := arr.Values()
+	if err != nil || len(values) != numKeys {
+		return nil, ErrInvalidPageToken
+	}
+	return values, nil
+}
+
+// pageTokenMAC returns the HMAC-SHA256 of a page token payload. It also covers the namespace and the sort order, so a
+// token cannot be used with another collection or sort order.
+func (coll *Collection) pageTokenMAC(payload, sort bsoncore.Document, signingKey []byte) []byte {
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write([]byte(coll.db.name + "." + coll.name))
+	mac.Write([]byte{0})
+	mac.Write(sort)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,188 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/bsontype"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+)
+
+// ParallelScan splits the documents of the collection into at most n ranges of the values of a field and returns an
+// independent Cursor for each range. Together, the cursors return every document selected by the Filter option
+// exactly once, so they can be iterated concurrently by different goroutines.
+//
+// The range boundaries are computed with a $sample of the collection grouped by $bucketAuto, so the ranges hold
+// roughly the same number of documents. Fewer than n cursors are returned if the sample does not have enough distinct
+// values. The boundaries all have the same BSON type, which is the most common type in the sample. Documents whose
+// field is missing, null or of another type are returned by the first cursor.
+//
+// The sampling aggregation and the find commands use the read preference of the collection. To spread the load across
+// secondaries, use a collection created with Clone and a secondary read preference.
+//
+// The opts parameter can be used to specify options for the operation (see the options.ParallelScanOptions
+// documentation).
+func (coll *Collection) ParallelScan(ctx context.Context, n int,
+	opts ...*options.ParallelScanOptions) ([]*Cursor, error) {
+
+	if n <= 0 {
+		return nil, fmt.Errorf("number of partitions must be positive, but was %d", n)
+	}
+	pso := options.MergeParallelScanOptions(opts...)
+
+	field := "_id"
+	if pso.Field != nil {
+		field = *pso.Field
+	}
+	filter := bsoncore.NewDocumentBuilder().Build()
+	if pso.Filter != nil {
+		var err error
+		filter, err = marshal(pso.Filter, coll.bsonOpts, coll.registry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var boundaries []bsoncore.Value
+	if n > 1 {
+		var err error
+		boundaries, err = coll.partitionBoundaries(ctx, field, filter, n, pso)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	filters := partitionFilters(field, filter, boundaries)
+	cursors := make([]*Cursor, 0, len(filters))
+	for _, f := range filters {
+		cursor, err := coll.Find(ctx, bson.Raw(f), pso.FindOptions)
+		if err != nil {
+			for _, c := range cursors {
+				_ = c.Close(ctx)
+			}
+			return nil, err
+		}
+		cursors = append(cursors, cursor)
+	}
+	return cursors, nil
+}
+
+// partitionBoundaries returns the ascending lower bounds of all but the first of n ranges of the values of field.
+func (coll *Collection) partitionBoundaries(ctx context.Context, field string, filter bsoncore.Document, n int,
+	pso *options.ParallelScanOptions) ([]bsoncore.Value, error) {
+
+	sampleSize := int32(100 * n)
+	if pso.SampleSize != nil {
+		sampleSize = *pso.SampleSize
+	}
+
+	var pipeline Pipeline
+	if len(filter) > 5 {
+		pipeline = append(pipeline, bson.D{{"$match", bson.Raw(filter)}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{"$sample", bson.D{{"size", sampleSize}}}},
+		bson.D{{"$bucketAuto", bson.D{{"groupBy", "$" + field}, {"buckets", n}}}},
+	)
+	aggOpts := options.Aggregate()
+	if pso.FindOptions != nil && pso.FindOptions.Collation != nil {
+		aggOpts.SetCollation(pso.FindOptions.Collation)
+	}
+
+	cursor, err := coll.Aggregate(ctx, pipeline, aggOpts)
+	if err != nil {
+		return nil, err
+	}
+	var buckets []bson.Raw
+	if err := cursor.All(ctx, &buckets); err != nil {
+		return nil, err
+	}
+
+	// The minimum of the first bucket is not a boundary because the first range has no lower bound.
+	var candidates []bsoncore.Value
+	counts := make(map[bsontype.Type]int)
+	for i := 1; i < len(buckets); i++ {
+		val, err := buckets[i].LookupErr("_id", "min")
+		if err != nil {
+			return nil, fmt.Errorf("unexpected $bucketAuto result %s", buckets[i])
+		}
+		switch val.Type {
+		case bson.TypeNull, bson.TypeUndefined, bson.TypeMinKey, bson.TypeMaxKey:
+			continue
+		}
+		candidates = append(candidates, bsoncore.Value{Type: val.Type, Data: val.Value})
+		counts[typeBracket(val.Type)]++
+	}
+
+	var bracket bsontype.Type
+	for t, count := range counts {
+		if count > counts[bracket] || (count == counts[bracket] && t < bracket) {
+			bracket = t
+		}
+	}
+	var boundaries []bsoncore.Value
+	for _, val := range candidates {
+		if typeBracket(val.Type) != bracket {
+			continue
+		}
+		if last := len(boundaries) - 1; last >= 0 &&
+			boundaries[last].Type == val.Type && bytes.Equal(boundaries[last].Data, val.Data) {
+			continue
+		}
+		boundaries = append(boundaries, val)
+	}
+	return boundaries, nil
+}
+
+// partitionFilters returns the filters of the ranges of field delimited by boundaries, combined with filter. The first
+// range matches every document whose value is not greater than or equal to the first boundary, which includes values
+// of other types and missing values.
+func partitionFilters(field string, filter bsoncore.Document, boundaries []bsoncore.Value) []bsoncore.Document {
+	if len(boundaries) == 0 {
+		return []bsoncore.Document{filter}
+	}
+
+	ranges := make([]bsoncore.Document, 0, len(boundaries)+1)
+	ranges = append(ranges, bsoncore.NewDocumentBuilder().
+		AppendDocument(field, bsoncore.NewDocumentBuilder().
+			AppendDocument("$not", bsoncore.NewDocumentBuilder().AppendValue("$gte", boundaries[0]).Build()).
+			Build()).
+		Build())
+	for i, lower := range boundaries {
+		cond := bsoncore.NewDocumentBuilder().AppendValue("$gte", lower)
+		if i+1 < len(boundaries) {
+			cond.AppendValue("$lt", boundaries[i+1])
+		}
+		ranges = append(ranges, bsoncore.NewDocumentBuilder().AppendDocument(field, cond.Build()).Build())
+	}
+
+	if len(filter) <= 5 {
+		return ranges
+	}
+	for i, r := range ranges {
+		ranges[i] = bsoncore.NewDocumentBuilder().
+			AppendArray("$and", bsoncore.NewArrayBuilder().AppendDocument(filter).AppendDocument(r).Build()).
+			Build()
+	}
+	return ranges
+}
+
+// typeBracket returns the type that represents the group of BSON types that query operators compare with each other.
+func typeBracket(t bsontype.Type) bsontype.Type {
+	switch t {
+	case bsontype.Int32, bsontype.Int64, bsontype.Decimal128:
+		return bsontype.Double
+	case bsontype.Symbol:
+		return bsontype.String
+	}
+	return t
+}
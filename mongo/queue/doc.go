@@ -0,0 +1,50 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package queue provides a durable work queue stored in a MongoDB collection.
+//
+// # Jobs
+//
+// A job is a document with a payload, a priority and the time at which it becomes visible to workers. Dequeue
+// atomically takes the visible job with the highest priority and hides it for the VisibilityTimeout. The worker then
+// acknowledges the job with Ack, which removes it, or rejects it with Nack, which makes it visible again. A job that is
+// neither acknowledged nor rejected becomes visible again when its visibility timeout expires, so jobs are processed at
+// least once and handlers must be idempotent.
+//
+//	q, err := queue.NewQueue(ctx, db.Collection("jobs"))
+//	if err != nil {
+//		return err
+//	}
+//	defer q.Close()
+//
+//	_, err = q.Enqueue(ctx, bson.D{{"email", "user@example.com"}}, options.Enqueue().SetPriority(10))
+//	...
+//	for {
+//		job, err := q.Dequeue(ctx)
+//		if err != nil {
+//			return err
+//		}
+//		if err := sendEmail(ctx, job); err != nil {
+//			_ = job.Nack(ctx, err)
+//			continue
+//		}
+//		_ = job.Ack(ctx)
+//	}
+//
+// # Dead letters
+//
+// A job is delivered at most MaxAttempts times. When its last attempt fails or times out, it is moved to the dead
+// letter collection together with its last error.
+//
+// # Wakeups
+//
+// Idle workers are woken up by a change stream on the queue collection when a job is enqueued or becomes visible, and
+// by a timer for delayed jobs, so they do not poll. If change streams are not available, for example on a standalone
+// server, idle workers check the queue every PollInterval.
+//
+// Visibility times are computed with the clock of the process that enqueues or dequeues a job, so the clocks of the
+// workers should be synchronized.
+package queue // import "github.com/zhangdapeng520/zdpgo_mongo/mongo/queue"
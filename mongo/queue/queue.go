@@ -0,0 +1,512 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/primitive"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+)
+
+const (
+	// DefaultVisibilityTimeout is the default amount of time that a dequeued job is hidden from other workers.
+	DefaultVisibilityTimeout = 30 * time.Second
+
+	// DefaultMaxAttempts is the default maximum number of times a job is delivered.
+	DefaultMaxAttempts int32 = 5
+
+	// DefaultPollInterval is the default maximum amount of time that an idle Dequeue waits.
+	DefaultPollInterval = 10 * time.Second
+)
+
+// ErrNoJob is returned by TryDequeue if no job is visible.
+var ErrNoJob = errors.New("no job is ready")
+
+// ErrJobLost is returned by the methods of Job if the visibility timeout of the job expired and it was delivered
+// again or dead-lettered.
+var ErrJobLost = errors.New("job was redelivered after its visibility timeout expired")
+
+// ErrQueueClosed is returned by Dequeue if the queue is closed while it waits.
+var ErrQueueClosed = errors.New("queue is closed")
+
+// jobDocument is the document that stores a job.
+type jobDocument struct {
+	ID         primitive.ObjectID  `bson:"_id"`
+	Payload    bson.RawValue       `bson:"payload"`
+	Priority   int32               `bson:"priority"`
+	Attempts   int32               `bson:"attempts"`
+	EnqueuedAt time.Time           `bson:"enqueuedAt"`
+	VisibleAt  time.Time           `bson:"visibleAt"`
+	Receipt    *primitive.ObjectID `bson:"receipt,omitempty"`
+	LastError  string              `bson:"lastError,omitempty"`
+}
+
+// deadLetterDocument is the document that stores a job in the dead letter collection.
+type deadLetterDocument struct {
+	jobDocument `bson:",inline"`
+	FailedAt    time.Time `bson:"failedAt"`
+}
+
+// Queue is a work queue stored in a collection. It is safe for concurrent use by multiple goroutines.
+type Queue struct {
+	coll              *mongo.Collection
+	dead              *mongo.Collection
+	visibilityTimeout time.Duration
+	maxAttempts       int32
+	retryDelay        time.Duration
+	pollInterval      time.Duration
+
+	watchOnce sync.Once
+	stopWatch context.CancelFunc
+	watchDone chan struct{}
+
+	mu     sync.Mutex
+	wake   chan struct{}
+	closed chan struct{}
+}
+
+// NewQueue creates a Queue stored in coll, and creates the indexes used to dequeue jobs. The queue must be closed with
+// Close to stop watching for new jobs.
+//
+// The opts parameter can be used to specify options for the queue (see the options.QueueOptions documentation).
+func NewQueue(ctx context.Context, coll *mongo.Collection, opts ...*options.QueueOptions) (*Queue, error) {
+	qo := options.MergeQueueOptions(opts...)
+
+	q := &Queue{
+		coll:              coll,
+		visibilityTimeout: DefaultVisibilityTimeout,
+		maxAttempts:       DefaultMaxAttempts,
+		pollInterval:      DefaultPollInterval,
+		wake:              make(chan struct{}),
+		closed:            make(chan struct{}),
+	}
+	if qo.VisibilityTimeout != nil {
+		if *qo.VisibilityTimeout <= 0 {
+			return nil, fmt.Errorf("visibility timeout must be positive, but was %v", *qo.VisibilityTimeout)
+		}
+		q.visibilityTimeout = *qo.VisibilityTimeout
+	}
+	if qo.MaxAttempts != nil {
+		if *qo.MaxAttempts <= 0 {
+			return nil, fmt.Errorf("maximum attempts must be positive, but was %d", *qo.MaxAttempts)
+		}
+		q.maxAttempts = *qo.MaxAttempts
+	}
+	if qo.RetryDelay != nil {
+		if *qo.RetryDelay < 0 {
+			return nil, fmt.Errorf("retry delay must not be negative, but was %v", *qo.RetryDelay)
+		}
+		q.retryDelay = *qo.RetryDelay
+	}
+	if qo.PollInterval != nil {
+		if *qo.PollInterval <= 0 {
+			return nil, fmt.Errorf("poll interval must be positive, but was %v", *qo.PollInterval)
+		}
+		q.pollInterval = *qo.PollInterval
+	}
+	deadName := coll.Name() + "_dead"
+	if qo.DeadLetterCollection != nil {
+		deadName = *qo.DeadLetterCollection
+	}
+	q.dead = coll.Database().Collection(deadName)
+
+	// The first index serves TryDequeue, and the second one lets idle workers find when the next job becomes visible.
+	_, err := coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{"priority", -1}, {"visibleAt", 1}}},
+		{Keys: bson.D{{"visibleAt", 1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Close stops watching for new jobs. Dequeue calls that are waiting return ErrQueueClosed. Close is idempotent.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	select {
+	case <-q.closed:
+		q.mu.Unlock()
+		return
+	default:
+	}
+	close(q.closed)
+	q.mu.Unlock()
+
+	q.watchOnce.Do(func() {})
+	if q.stopWatch != nil {
+		q.stopWatch()
+		<-q.watchDone
+	}
+}
+
+// Enqueue adds a job with payload to the queue and returns its ID. The payload can be any value that can be
+// marshalled to BSON.
+//
+// The opts parameter can be used to specify options for the job (see the options.EnqueueOptions documentation).
+func (q *Queue) Enqueue(ctx context.Context, payload interface{},
+	opts ...*options.EnqueueOptions) (primitive.ObjectID, error) {
+
+	eo := options.MergeEnqueueOptions(opts...)
+
+	now := time.Now()
+	visibleAt := now
+	if eo.Delay != nil {
+		visibleAt = visibleAt.Add(*eo.Delay)
+	}
+	var priority int32
+	if eo.Priority != nil {
+		priority = *eo.Priority
+	}
+
+	id := primitive.NewObjectID()
+	_, err := q.coll.InsertOne(ctx, bson.D{
+		{"_id", id},
+		{"payload", payload},
+		{"priority", priority},
+		{"attempts", int32(0)},
+		{"enqueuedAt", now},
+		{"visibleAt", visibleAt},
+	})
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return id, nil
+}
+
+// TryDequeue takes the visible job with the highest priority and hides it for the visibility timeout. It returns
+// ErrNoJob if no job is visible.
+func (q *Queue) TryDequeue(ctx context.Context) (*Job, error) {
+	for {
+		now := time.Now()
+		receipt := primitive.NewObjectID()
+		filter := bson.D{{"visibleAt", bson.D{{"$lte", now}}}}
+		update := bson.D{
+			{"$set", bson.D{{"visibleAt", now.Add(q.visibilityTimeout)}, {"receipt", receipt}}},
+			{"$inc", bson.D{{"attempts", int32(1)}}},
+		}
+		opts := options.FindOneAndUpdate().
+			SetSort(bson.D{{"priority", -1}, {"visibleAt", 1}}).
+			SetReturnDocument(options.After)
+
+		var doc jobDocument
+		err := q.coll.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNoJob
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		job := &Job{
+			ID:         doc.ID,
+			Payload:    doc.Payload,
+			Priority:   doc.Priority,
+			Attempts:   doc.Attempts,
+			EnqueuedAt: doc.EnqueuedAt,
+			LastError:  doc.LastError,
+			q:          q,
+			doc:        doc,
+			receipt:    receipt,
+		}
+		if doc.Attempts <= q.maxAttempts {
+			return job, nil
+		}
+
+		// The visibility timeout of the last attempt expired.
+		if err := job.deadLetter(ctx, "visibility timeout expired"); err != nil && !errors.Is(err, ErrJobLost) {
+			return nil, err
+		}
+	}
+}
+
+// Dequeue takes the visible job with the highest priority and hides it for the visibility timeout. If no job is
+// visible, it waits until a job is enqueued or becomes visible, ctx is done, or the queue is closed.
+func (q *Queue) Dequeue(ctx context.Context) (*Job, error) {
+	q.watchOnce.Do(q.startWatch)
+
+	for {
+		// The wake channel is read before checking the queue so that a job enqueued in between is not missed.
+		q.mu.Lock()
+		wake := q.wake
+		q.mu.Unlock()
+
+		job, err := q.TryDequeue(ctx)
+		if !errors.Is(err, ErrNoJob) {
+			return job, err
+		}
+
+		wait := q.pollInterval
+		next, err := q.nextVisibleAt(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !next.IsZero() {
+			if d := time.Until(next); d < wait {
+				wait = d
+			}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-wake:
+		case <-timer.C:
+		case <-q.closed:
+			timer.Stop()
+			return nil, ErrQueueClosed
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+		timer.Stop()
+	}
+}
+
+// Stats holds the number of jobs in each state.
+type Stats struct {
+	// Ready is the number of jobs that can be dequeued now.
+	Ready int64 `bson:"ready"`
+
+	// Delayed is the number of jobs that were enqueued with a delay or rejected with a retry delay and are not visible
+	// yet.
+	Delayed int64 `bson:"delayed"`
+
+	// InFlight is the number of jobs that have been dequeued and are hidden by their visibility timeout.
+	InFlight int64 `bson:"inFlight"`
+
+	// Dead is the number of jobs in the dead letter collection.
+	Dead int64 `bson:"dead"`
+}
+
+// Stats returns the number of jobs in each state, counted with aggregations on the queue and dead letter collections.
+func (q *Queue) Stats(ctx context.Context) (*Stats, error) {
+	now := time.Now()
+	visible := bson.D{{"$lte", bson.A{"$visibleAt", now}}}
+	dequeued := bson.D{{"$ne", bson.A{bson.D{{"$type", "$receipt"}}, "missing"}}}
+	count := func(cond bson.D) bson.D {
+		return bson.D{{"$sum", bson.D{{"$cond", bson.A{cond, 1, 0}}}}}
+	}
+	pipeline := mongo.Pipeline{
+		{{"$group", bson.D{
+			{"_id", nil},
+			{"ready", count(visible)},
+			{"delayed", count(bson.D{{"$and", bson.A{bson.D{{"$not", bson.A{visible}}}, bson.D{{"$not", bson.A{dequeued}}}}}})},
+			{"inFlight", count(bson.D{{"$and", bson.A{bson.D{{"$not", bson.A{visible}}}, dequeued}}})},
+		}}},
+	}
+
+	cursor, err := q.coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var results []Stats
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	stats := &Stats{}
+	if len(results) > 0 {
+		*stats = results[0]
+	}
+	stats.Dead, err = q.dead.CountDocuments(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// nextVisibleAt returns the earliest time at which a job becomes visible, or the zero time if the queue is empty.
+func (q *Queue) nextVisibleAt(ctx context.Context) (time.Time, error) {
+	var doc struct {
+		VisibleAt time.Time `bson:"visibleAt"`
+	}
+	// The query is covered by the visibleAt index.
+	opts := options.FindOne().
+		SetSort(bson.D{{"visibleAt", 1}}).
+		SetProjection(bson.D{{"_id", 0}, {"visibleAt", 1}})
+	err := q.coll.FindOne(ctx, bson.D{}, opts).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return time.Time{}, nil
+	}
+	return doc.VisibleAt, err
+}
+
+// startWatch starts a goroutine that wakes up the waiting Dequeue calls when a job is enqueued or rejected.
+func (q *Queue) startWatch() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	select {
+	case <-q.closed:
+		return
+	default:
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q.stopWatch = cancel
+	q.watchDone = make(chan struct{})
+	go q.watch(ctx)
+}
+
+// watch watches the queue collection until ctx is done. If the change stream cannot be opened or fails, it is
+// reopened after the poll interval, and Dequeue falls back to polling in the meantime.
+func (q *Queue) watch(ctx context.Context) {
+	defer close(q.watchDone)
+
+	// Dequeuing a job is also an update, so only updates that make a job visible again by removing its receipt wake
+	// up the workers.
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.D{{"$or", bson.A{
+			bson.D{{"operationType", "insert"}},
+			bson.D{{"updateDescription.removedFields", "receipt"}},
+		}}}}},
+	}
+	for {
+		cs, err := q.coll.Watch(ctx, pipeline)
+		if err == nil {
+			for cs.Next(ctx) {
+				q.notify()
+			}
+			_ = cs.Close(context.Background())
+		}
+
+		timer := time.NewTimer(q.pollInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// notify wakes up the waiting Dequeue calls.
+func (q *Queue) notify() {
+	q.mu.Lock()
+	close(q.wake)
+	q.wake = make(chan struct{})
+	q.mu.Unlock()
+}
+
+// Job is a job dequeued from a Queue.
+type Job struct {
+	// ID is the ID returned by Enqueue.
+	ID primitive.ObjectID
+
+	// Payload is the payload passed to Enqueue.
+	Payload bson.RawValue
+
+	// Priority is the priority of the job.
+	Priority int32
+
+	// Attempts is the number of times the job has been delivered, including this one.
+	Attempts int32
+
+	// EnqueuedAt is the time at which the job was enqueued.
+	EnqueuedAt time.Time
+
+	// LastError is the error passed to Nack by the previous attempt, if any.
+	LastError string
+
+	q       *Queue
+	doc     jobDocument
+	receipt primitive.ObjectID
+}
+
+// Decode unmarshals the payload of the job into val.
+func (j *Job) Decode(val interface{}) error {
+	return j.Payload.Unmarshal(val)
+}
+
+// Ack acknowledges the job and removes it from the queue.
+func (j *Job) Ack(ctx context.Context) error {
+	res, err := j.q.coll.DeleteOne(ctx, j.filter())
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrJobLost
+	}
+	return nil
+}
+
+// Nack rejects the job because of cause. The job becomes visible again after the retry delay, or is moved to the dead
+// letter collection if this was its last attempt.
+func (j *Job) Nack(ctx context.Context, cause error) error {
+	var lastError string
+	if cause != nil {
+		lastError = cause.Error()
+	}
+	if j.Attempts >= j.q.maxAttempts {
+		return j.deadLetter(ctx, lastError)
+	}
+
+	update := bson.D{
+		{"$set", bson.D{{"visibleAt", time.Now().Add(j.q.retryDelay)}, {"lastError", lastError}}},
+		{"$unset", bson.D{{"receipt", ""}}},
+	}
+	res, err := j.q.coll.UpdateOne(ctx, j.filter(), update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrJobLost
+	}
+	return nil
+}
+
+// Extend hides the job from other workers for d from now, for jobs that take longer than the visibility timeout.
+func (j *Job) Extend(ctx context.Context, d time.Duration) error {
+	update := bson.D{{"$set", bson.D{{"visibleAt", time.Now().Add(d)}}}}
+	res, err := j.q.coll.UpdateOne(ctx, j.filter(), update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrJobLost
+	}
+	return nil
+}
+
+// deadLetter moves the job to the dead letter collection. The job is inserted in the dead letter collection before it
+// is removed from the queue, so it is not lost if the removal fails.
+func (j *Job) deadLetter(ctx context.Context, lastError string) error {
+	doc := deadLetterDocument{jobDocument: j.doc, FailedAt: time.Now()}
+	doc.Receipt = nil
+	doc.LastError = lastError
+
+	inserted := true
+	if _, err := j.q.dead.InsertOne(ctx, doc); err != nil {
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+		inserted = false
+	}
+
+	res, err := j.q.coll.DeleteOne(ctx, j.filter())
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		if inserted {
+			_, _ = j.q.dead.DeleteOne(ctx, bson.D{{"_id", j.ID}})
+		}
+		return ErrJobLost
+	}
+	return nil
+}
+
+// filter returns the filter that matches the job only while it is held by this delivery.
+func (j *Job) filter() bson.D {
+	return bson.D{{"_id", j.ID}, {"receipt", j.receipt}}
+}
@@ -7,6 +7,7 @@
 package mongo
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/zhangdapeng520/zdpgo_mongo/bson"
@@ -98,6 +99,31 @@ type UpdateResult struct {
 	UpsertedID    interface{} // The _id field of the upserted document, or nil if no upsert was done.
 }
 
+// ErrVersionConflict is returned by the update and replace methods of VersionedCollection if no document matched the
+// filter and the expected version. All errors returned for this reason are VersionConflictError values that match
+// ErrVersionConflict with errors.Is.
+var ErrVersionConflict = errors.New("mongo: version conflict")
+
+// VersionConflictError is the error returned by VersionedCollection when no document matched the filter and the
+// expected version, either because the document was modified concurrently or because it does not exist.
+type VersionConflictError struct {
+	// The name of the version field.
+	Field string
+
+	// The version that the document was expected to have.
+	Version int64
+}
+
+// Error implements the error interface.
+func (e VersionConflictError) Error() string {
+	return fmt.Sprintf("%s: no document matched with %s %d", ErrVersionConflict, e.Field, e.Version)
+}
+
+// Is returns true if target is ErrVersionConflict.
+func (e VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
+
 // UnmarshalBSON implements the bson.Unmarshaler interface.
 //
 // Deprecated: Unmarshalling an UpdateResult directly from BSON is not supported and may produce
@@ -284,3 +310,73 @@ func (cs *CollectionSpecification) UnmarshalBSON(data []byte) error {
 	cs.IDIndex = temp.IDIndex
 	return nil
 }
+
+// ClientBulkWriteResult is the result type returned by a Client.BulkWrite operation.
+type ClientBulkWriteResult struct {
+	// The number of documents inserted.
+	InsertedCount int64
+
+	// The number of documents matched by filters in update and replace operations.
+	MatchedCount int64
+
+	// The number of documents modified by update and replace operations.
+	ModifiedCount int64
+
+	// The number of documents deleted.
+	DeletedCount int64
+
+	// The number of documents upserted by update and replace operations.
+	UpsertedCount int64
+
+	// The counts for each namespace targeted by the operation, keyed by "<database>.<collection>". Only writes that
+	// succeeded are counted.
+	NamespaceResults map[string]ClientBulkWriteNamespaceResult
+
+	// A map of model index to the result of each successful insert. This is only set if the VerboseResults option
+	// was set to true.
+	InsertResults map[int]ClientInsertResult
+
+	// A map of model index to the result of each successful update or replace. This is only set if the
+	// VerboseResults option was set to true.
+	UpdateResults map[int]ClientUpdateResult
+
+	// A map of model index to the result of each successful delete. This is only set if the VerboseResults option
+	// was set to true.
+	DeleteResults map[int]ClientDeleteResult
+
+	// Whether the operation was acknowledged by the server. If false, all other fields are their zero values.
+	Acknowledged bool
+}
+
+// ClientBulkWriteNamespaceResult contains the counts for a single namespace targeted by a Client.BulkWrite operation.
+type ClientBulkWriteNamespaceResult struct {
+	InsertedCount int64
+	MatchedCount  int64
+	ModifiedCount int64
+	DeletedCount  int64
+	UpsertedCount int64
+}
+
+// ClientInsertResult is the result of an individual insert in a Client.BulkWrite operation.
+type ClientInsertResult struct {
+	// The _id of the inserted document. A value generated by the driver will be of type primitive.ObjectID.
+	InsertedID interface{}
+}
+
+// ClientUpdateResult is the result of an individual update or replace in a Client.BulkWrite operation.
+type ClientUpdateResult struct {
+	// The number of documents matched by the filter.
+	MatchedCount int64
+
+	// The number of documents modified.
+	ModifiedCount int64
+
+	// The _id of the upserted document, or nil if no document was upserted.
+	UpsertedID interface{}
+}
+
+// ClientDeleteResult is the result of an individual delete in a Client.BulkWrite operation.
+type ClientDeleteResult struct {
+	// The number of documents deleted.
+	DeletedCount int64
+}
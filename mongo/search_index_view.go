@@ -139,7 +139,7 @@ func (siv SearchIndexView) CreateMany(
 	selector := makePinnedSelector(sess, siv.coll.writeSelector)
 
 	op := operation.NewCreateSearchIndexes(indexes).
-		Session(sess).CommandMonitor(siv.coll.client.monitor).
+		Session(sess).CommandMonitor(siv.coll.client.monitor).Interceptors(siv.coll.client.interceptors).
 		ServerSelector(selector).ClusterClock(siv.coll.client.clock).
 		Collection(siv.coll.name).Database(siv.coll.db.name).
 		Deployment(siv.coll.client.deployment).ServerAPI(siv.coll.client.serverAPI).
@@ -194,7 +194,7 @@ func (siv SearchIndexView) DropOne(
 	selector := makePinnedSelector(sess, siv.coll.writeSelector)
 
 	op := operation.NewDropSearchIndex(name).
-		Session(sess).CommandMonitor(siv.coll.client.monitor).
+		Session(sess).CommandMonitor(siv.coll.client.monitor).Interceptors(siv.coll.client.interceptors).
 		ServerSelector(selector).ClusterClock(siv.coll.client.clock).
 		Collection(siv.coll.name).Database(siv.coll.db.name).
 		Deployment(siv.coll.client.deployment).ServerAPI(siv.coll.client.serverAPI).
@@ -248,7 +248,7 @@ func (siv SearchIndexView) UpdateOne(
 	selector := makePinnedSelector(sess, siv.coll.writeSelector)
 
 	op := operation.NewUpdateSearchIndex(name, indexDefinition).
-		Session(sess).CommandMonitor(siv.coll.client.monitor).
+		Session(sess).CommandMonitor(siv.coll.client.monitor).Interceptors(siv.coll.client.interceptors).
 		ServerSelector(selector).ClusterClock(siv.coll.client.clock).
 		Collection(siv.coll.name).Database(siv.coll.db.name).
 		Deployment(siv.coll.client.deployment).ServerAPI(siv.coll.client.serverAPI).
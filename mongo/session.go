@@ -9,10 +9,13 @@ package mongo
 import (
 	"context"
 	"errors"
+	"math"
 	"time"
 
 	"github.com/zhangdapeng520/zdpgo_mongo/bson"
 	"github.com/zhangdapeng520/zdpgo_mongo/bson/primitive"
+	"github.com/zhangdapeng520/zdpgo_mongo/event"
+	"github.com/zhangdapeng520/zdpgo_mongo/internal/randutil"
 	"github.com/zhangdapeng520/zdpgo_mongo/mongo/description"
 	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
 	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
@@ -27,6 +30,8 @@ var ErrWrongClient = errors.New("session was not created by this client")
 
 var withTransactionTimeout = 120 * time.Second
 
+var random = randutil.NewLockedRand()
+
 // SessionContext combines the context.Context and mongo.Session interfaces. It should be used as the Context arguments
 // to operations that should be executed in a session.
 //
@@ -99,17 +104,19 @@ type Session interface {
 	// aborted.
 	CommitTransaction(context.Context) error
 
-	// WithTransaction starts a transaction on this session and runs the fn callback. Errors with
-	// the TransientTransactionError and UnknownTransactionCommitResult labels are retried for up to
-	// 120 seconds. Inside the callback, the SessionContext must be used as the Context parameter
-	// for any operations that should be part of the transaction. If the ctx parameter already has a
-	// Session attached to it, it will be replaced by this session. The fn callback may be run
-	// multiple times during WithTransaction due to retry attempts, so it must be idempotent.
-	// Non-retryable operation errors or any operation errors that occur after the timeout expires
-	// will be returned without retrying. If the callback fails, the driver will call
+	// WithTransaction starts a transaction on this session and runs the fn callback. Errors with the
+	// TransientTransactionError and UnknownTransactionCommitResult labels are retried for up to 120
+	// seconds by default. The number of attempts, the retry time and the backoff between retries can
+	// be configured with the TransactionOptions. Inside the callback, the SessionContext must be used
+	// as the Context parameter for any operations that should be part of the transaction. If the ctx
+	// parameter already has a Session attached to it, it will be replaced by this session. The fn
+	// callback may be run multiple times during WithTransaction due to retry attempts, so it must be
+	// idempotent. Non-retryable operation errors or any operation errors that occur after the timeout
+	// expires will be returned without retrying. If the callback fails, the driver will call
 	// AbortTransaction. Because this method must succeed to ensure that server-side resources are
-	// properly cleaned up, context deadlines and cancellations will not be respected during this
-	// call. For a usage example, see the Client.StartSession method documentation.
+	// properly cleaned up, context deadlines and cancellations will not be respected during this call.
+	// They do stop the wait between retries. For a usage example, see the Client.StartSession method
+	// documentation.
 	WithTransaction(ctx context.Context, fn func(ctx SessionContext) (interface{}, error),
 		opts ...*options.TransactionOptions) (interface{}, error)
 
@@ -122,6 +129,10 @@ type Session interface {
 	// OperationTime returns the current operation time document associated with the session.
 	OperationTime() *primitive.Timestamp
 
+	// CausalToken returns a token that holds the current cluster time and operation time of the session. The token
+	// can be used to start a causally consistent session in another process with Client.StartCausalSession.
+	CausalToken() *CausalToken
+
 	// Client the Client associated with the session.
 	Client() *Client
 
@@ -181,8 +192,7 @@ func (s *sessionImpl) EndSession(ctx context.Context) {
 // WithTransaction implements the Session interface.
 func (s *sessionImpl) WithTransaction(ctx context.Context, fn func(ctx SessionContext) (interface{}, error),
 	opts ...*options.TransactionOptions) (interface{}, error) {
-	timeout := time.NewTimer(withTransactionTimeout)
-	defer timeout.Stop()
+	policy := newTransactionRetryPolicy(options.MergeTransactionOptions(opts...))
 	var err error
 	for {
 		err = s.StartTransaction(opts...)
@@ -198,16 +208,13 @@ func (s *sessionImpl) WithTransaction(ctx context.Context, fn func(ctx SessionCo
 				_ = s.AbortTransaction(newBackgroundContext(ctx))
 			}
 
-			select {
-			case <-timeout.C:
-				return nil, err
-			default:
+			if !errorHasLabel(err, driver.TransientTransactionError) {
+				return res, err
 			}
-
-			if errorHasLabel(err, driver.TransientTransactionError) {
-				continue
+			if !s.retryTransaction(ctx, policy, err, false) {
+				return nil, err
 			}
-			return res, err
+			continue
 		}
 
 		// Check if callback intentionally aborted and, if so, return immediately
@@ -239,18 +246,18 @@ func (s *sessionImpl) WithTransaction(ctx context.Context, fn func(ctx SessionCo
 				return res, nil
 			}
 
-			select {
-			case <-timeout.C:
-				return res, err
-			default:
-			}
-
 			if cerr, ok := err.(CommandError); ok {
 				if cerr.HasErrorLabel(driver.UnknownTransactionCommitResult) && !cerr.IsMaxTimeMSExpiredError() {
-					continue
+					if s.retryTransaction(ctx, policy, err, true) {
+						continue
+					}
+					return res, err
 				}
 				if cerr.HasErrorLabel(driver.TransientTransactionError) {
-					break CommitLoop
+					if s.retryTransaction(ctx, policy, err, false) {
+						break CommitLoop
+					}
+					return res, err
 				}
 			}
 			return res, err
@@ -258,6 +265,104 @@ func (s *sessionImpl) WithTransaction(ctx context.Context, fn func(ctx SessionCo
 	}
 }
 
+// transactionRetryPolicy tracks the attempts made by WithTransaction and decides whether and when to retry.
+type transactionRetryPolicy struct {
+	maxAttempts  int
+	maxRetryTime time.Duration
+	backoff      time.Duration
+	maxBackoff   time.Duration
+	onRetry      func(error, int)
+
+	start    time.Time
+	attempts int
+}
+
+func newTransactionRetryPolicy(topts *options.TransactionOptions) *transactionRetryPolicy {
+	p := &transactionRetryPolicy{
+		maxRetryTime: withTransactionTimeout,
+		onRetry:      topts.OnRetry,
+		start:        time.Now(),
+	}
+	if topts.MaxAttempts != nil {
+		p.maxAttempts = *topts.MaxAttempts
+	}
+	if topts.MaxRetryTime != nil {
+		p.maxRetryTime = *topts.MaxRetryTime
+	}
+	if topts.RetryBackoff != nil {
+		p.backoff = *topts.RetryBackoff
+	}
+	if topts.MaxRetryBackoff != nil {
+		p.maxBackoff = *topts.MaxRetryBackoff
+	}
+	return p
+}
+
+// next records a failed attempt and returns whether it can be retried and how long to wait before the retry.
+func (p *transactionRetryPolicy) next() (time.Duration, bool) {
+	p.attempts++
+	if p.maxAttempts > 0 && p.attempts >= p.maxAttempts {
+		return 0, false
+	}
+	elapsed := time.Since(p.start)
+	if elapsed >= p.maxRetryTime {
+		return 0, false
+	}
+	if p.backoff <= 0 {
+		return 0, true
+	}
+
+	delay := p.backoff
+	for i := 1; i < p.attempts && delay < math.MaxInt64/2; i++ {
+		delay *= 2
+		if p.maxBackoff > 0 && delay >= p.maxBackoff {
+			break
+		}
+	}
+	if p.maxBackoff > 0 && delay > p.maxBackoff {
+		delay = p.maxBackoff
+	}
+	delay = time.Duration(random.Int63n(int64(delay) + 1))
+	if remaining := p.maxRetryTime - elapsed; delay > remaining {
+		delay = remaining
+	}
+	return delay, true
+}
+
+// retryTransaction records a failed attempt of WithTransaction and, if the attempt can be retried, reports the retry
+// and waits for the backoff delay. It returns false if the attempt must not be retried or ctx is done while waiting.
+func (s *sessionImpl) retryTransaction(ctx context.Context, p *transactionRetryPolicy, err error, commit bool) bool {
+	delay, ok := p.next()
+	if !ok {
+		return false
+	}
+
+	if p.onRetry != nil {
+		p.onRetry(err, p.attempts)
+	}
+	if s.client.txnMonitor != nil && s.client.txnMonitor.Retry != nil {
+		s.client.txnMonitor.Retry(ctx, &event.TransactionRetryEvent{
+			SessionID:   bson.Raw(s.clientSession.SessionID),
+			Attempt:     p.attempts,
+			Err:         err,
+			CommitRetry: commit,
+			Delay:       delay,
+		})
+	}
+
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
 // StartTransaction implements the Session interface.
 func (s *sessionImpl) StartTransaction(opts ...*options.TransactionOptions) error {
 	err := s.clientSession.CheckStartTransaction()
@@ -295,7 +400,7 @@ func (s *sessionImpl) AbortTransaction(ctx context.Context) error {
 	s.clientSession.Aborting = true
 	_ = operation.NewAbortTransaction().Session(s.clientSession).ClusterClock(s.client.clock).Database("admin").
 		Deployment(s.deployment).WriteConcern(s.clientSession.CurrentWc).ServerSelector(selector).
-		Retry(driver.RetryOncePerCommand).CommandMonitor(s.client.monitor).
+		Retry(driver.RetryOncePerCommand).CommandMonitor(s.client.monitor).Interceptors(s.client.interceptors).
 		RecoveryToken(bsoncore.Document(s.clientSession.RecoveryToken)).ServerAPI(s.client.serverAPI).Execute(ctx)
 
 	s.clientSession.Aborting = false
@@ -327,7 +432,8 @@ func (s *sessionImpl) CommitTransaction(ctx context.Context) error {
 	op := operation.NewCommitTransaction().
 		Session(s.clientSession).ClusterClock(s.client.clock).Database("admin").Deployment(s.deployment).
 		WriteConcern(s.clientSession.CurrentWc).ServerSelector(selector).Retry(driver.RetryOncePerCommand).
-		CommandMonitor(s.client.monitor).RecoveryToken(bsoncore.Document(s.clientSession.RecoveryToken)).
+		CommandMonitor(s.client.monitor).
+		Interceptors(s.client.interceptors).RecoveryToken(bsoncore.Document(s.clientSession.RecoveryToken)).
 		ServerAPI(s.client.serverAPI).MaxTime(s.clientSession.CurrentMct)
 
 	err = op.Execute(ctx)
@@ -0,0 +1,285 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+)
+
+// LatencyStats contains the cumulative latency of one kind of operation run against a collection.
+type LatencyStats struct {
+	// The total latency of the operations in microseconds.
+	Latency int64 `bson:"latency"`
+
+	// The number of operations.
+	Ops int64 `bson:"ops"`
+}
+
+// CollectionLatencyStats contains the latency statistics of a collection, as returned in the latencyStats field of
+// a $collStats stage.
+type CollectionLatencyStats struct {
+	Reads        LatencyStats `bson:"reads"`
+	Writes       LatencyStats `bson:"writes"`
+	Commands     LatencyStats `bson:"commands"`
+	Transactions LatencyStats `bson:"transactions"`
+}
+
+// CollectionStats is the result type returned by Collection.Stats. When run against a sharded cluster, the sizes,
+// document counts and latencies are the sums of the values reported by each shard, and NIndexes is the largest value
+// reported by any shard.
+type CollectionStats struct {
+	// The namespace of the collection.
+	Namespace string
+
+	// The number of documents in the collection.
+	Count int64
+
+	// The uncompressed size of the documents in the collection, in bytes.
+	Size int64
+
+	// The average size of a document in the collection, in bytes.
+	AvgObjSize float64
+
+	// The storage allocated for the documents in the collection, in bytes.
+	StorageSize int64
+
+	// The storage allocated for the documents that can be reused, in bytes.
+	FreeStorageSize int64
+
+	// Whether the collection is capped.
+	Capped bool
+
+	// The number of indexes on the collection.
+	NIndexes int64
+
+	// The total size of all indexes on the collection, in bytes.
+	TotalIndexSize int64
+
+	// The size of each index on the collection in bytes, keyed by index name.
+	IndexSizes map[string]int64
+
+	// The sum of StorageSize and TotalIndexSize.
+	TotalSize int64
+
+	// The latency statistics of the collection.
+	Latency CollectionLatencyStats
+
+	// The statistics reported by each shard, keyed by shard name. This is only set when running against a sharded
+	// cluster.
+	Shards map[string]CollectionStats
+}
+
+// collStatsDocument is a document returned by a $collStats stage.
+type collStatsDocument struct {
+	Namespace    string                 `bson:"ns"`
+	Shard        string                 `bson:"shard"`
+	Count        int64                  `bson:"count"`
+	LatencyStats CollectionLatencyStats `bson:"latencyStats"`
+	StorageStats struct {
+		Size            int64            `bson:"size"`
+		Count           int64            `bson:"count"`
+		StorageSize     int64            `bson:"storageSize"`
+		FreeStorageSize int64            `bson:"freeStorageSize"`
+		Capped          bool             `bson:"capped"`
+		NIndexes        int64            `bson:"nindexes"`
+		TotalIndexSize  int64            `bson:"totalIndexSize"`
+		IndexSizes      map[string]int64 `bson:"indexSizes"`
+		TotalSize       int64            `bson:"totalSize"`
+	} `bson:"storageStats"`
+}
+
+func (doc collStatsDocument) stats() CollectionStats {
+	cs := CollectionStats{
+		Namespace:       doc.Namespace,
+		Count:           doc.Count,
+		Size:            doc.StorageStats.Size,
+		StorageSize:     doc.StorageStats.StorageSize,
+		FreeStorageSize: doc.StorageStats.FreeStorageSize,
+		Capped:          doc.StorageStats.Capped,
+		NIndexes:        doc.StorageStats.NIndexes,
+		TotalIndexSize:  doc.StorageStats.TotalIndexSize,
+		IndexSizes:      doc.StorageStats.IndexSizes,
+		TotalSize:       doc.StorageStats.TotalSize,
+		Latency:         doc.LatencyStats,
+	}
+	if cs.Count > 0 {
+		cs.AvgObjSize = float64(cs.Size) / float64(cs.Count)
+	}
+	return cs
+}
+
+// add sums the sizes, counts and latencies of other into cs.
+func (cs *CollectionStats) add(other CollectionStats) {
+	cs.Count += other.Count
+	cs.Size += other.Size
+	cs.StorageSize += other.StorageSize
+	cs.FreeStorageSize += other.FreeStorageSize
+	cs.TotalIndexSize += other.TotalIndexSize
+	cs.TotalSize += other.TotalSize
+	cs.Capped = cs.Capped || other.Capped
+	if other.NIndexes > cs.NIndexes {
+		cs.NIndexes = other.NIndexes
+	}
+	if cs.IndexSizes == nil {
+		cs.IndexSizes = make(map[string]int64, len(other.IndexSizes))
+	}
+	for name, size := range other.IndexSizes {
+		cs.IndexSizes[name] += size
+	}
+
+	for _, l := range []struct{ dst, src *LatencyStats }{
+		{&cs.Latency.Reads, &other.Latency.Reads},
+		{&cs.Latency.Writes, &other.Latency.Writes},
+		{&cs.Latency.Commands, &other.Latency.Commands},
+		{&cs.Latency.Transactions, &other.Latency.Transactions},
+	} {
+		l.dst.Latency += l.src.Latency
+		l.dst.Ops += l.src.Ops
+	}
+
+	cs.AvgObjSize = 0
+	if cs.Count > 0 {
+		cs.AvgObjSize = float64(cs.Size) / float64(cs.Count)
+	}
+}
+
+// Stats returns the storage, count and latency statistics of the collection using a $collStats aggregation. The
+// aggregation is run with the read preference of the collection.
+//
+// For more information about the aggregation stage, see
+// https://www.mongodb.com/docs/manual/reference/operator/aggregation/collStats/.
+func (coll *Collection) Stats(ctx context.Context) (*CollectionStats, error) {
+	pipeline := Pipeline{{{"$collStats", bson.D{
+		{"latencyStats", bson.D{}},
+		{"storageStats", bson.D{}},
+		{"count", bson.D{}},
+	}}}}
+
+	cursor, err := coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []collStatsDocument
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, errors.New("$collStats returned no documents")
+	}
+	if len(docs) == 1 && docs[0].Shard == "" {
+		cs := docs[0].stats()
+		return &cs, nil
+	}
+
+	merged := CollectionStats{
+		Namespace: docs[0].Namespace,
+		Shards:    make(map[string]CollectionStats, len(docs)),
+	}
+	for _, doc := range docs {
+		shard := doc.stats()
+		merged.Shards[doc.Shard] = shard
+		merged.add(shard)
+	}
+	return &merged, nil
+}
+
+// DatabaseStats is the result type returned by Database.Stats. When run against a sharded cluster, the sizes and
+// document counts are the sums of the values reported by each shard, and the collection, view and index counts are
+// the largest values reported by any shard.
+type DatabaseStats struct {
+	// The name of the database.
+	Database string `bson:"db"`
+
+	// The number of collections in the database.
+	Collections int64 `bson:"collections"`
+
+	// The number of views in the database.
+	Views int64 `bson:"views"`
+
+	// The number of documents in the database.
+	Objects int64 `bson:"objects"`
+
+	// The average size of a document in the database, in bytes.
+	AvgObjSize float64 `bson:"avgObjSize"`
+
+	// The uncompressed size of the documents in the database, in bytes.
+	DataSize int64 `bson:"dataSize"`
+
+	// The storage allocated for the documents in the database, in bytes.
+	StorageSize int64 `bson:"storageSize"`
+
+	// The number of indexes in the database.
+	Indexes int64 `bson:"indexes"`
+
+	// The total size of all indexes in the database, in bytes.
+	IndexSize int64 `bson:"indexSize"`
+
+	// The sum of StorageSize and IndexSize.
+	TotalSize int64 `bson:"totalSize"`
+
+	// The storage used and the total storage of the filesystem the database is stored on, in bytes.
+	FSUsedSize  int64 `bson:"fsUsedSize"`
+	FSTotalSize int64 `bson:"fsTotalSize"`
+
+	// The statistics reported by each shard, keyed by shard name. This is only set when running against a sharded
+	// cluster.
+	Shards map[string]DatabaseStats `bson:"raw"`
+}
+
+// add sums the sizes and counts of other into ds.
+func (ds *DatabaseStats) add(other DatabaseStats) {
+	ds.Objects += other.Objects
+	ds.DataSize += other.DataSize
+	ds.StorageSize += other.StorageSize
+	ds.IndexSize += other.IndexSize
+	ds.TotalSize += other.TotalSize
+	ds.FSUsedSize += other.FSUsedSize
+	ds.FSTotalSize += other.FSTotalSize
+	if other.Collections > ds.Collections {
+		ds.Collections = other.Collections
+	}
+	if other.Views > ds.Views {
+		ds.Views = other.Views
+	}
+	if other.Indexes > ds.Indexes {
+		ds.Indexes = other.Indexes
+	}
+
+	ds.AvgObjSize = 0
+	if ds.Objects > 0 {
+		ds.AvgObjSize = float64(ds.DataSize) / float64(ds.Objects)
+	}
+}
+
+// Stats executes a dbStats command and returns the storage statistics of the database.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/dbStats/.
+func (db *Database) Stats(ctx context.Context) (*DatabaseStats, error) {
+	res := db.RunCommand(ctx, bson.D{{"dbStats", 1}})
+
+	var ds DatabaseStats
+	if err := res.Decode(&ds); err != nil {
+		return nil, err
+	}
+	if len(ds.Shards) == 0 {
+		ds.Shards = nil
+		return &ds, nil
+	}
+
+	merged := DatabaseStats{
+		Database: ds.Database,
+		Shards:   ds.Shards,
+	}
+	for _, shard := range ds.Shards {
+		merged.add(shard)
+	}
+	return &merged, nil
+}
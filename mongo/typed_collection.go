@@ -0,0 +1,221 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+)
+
+// TypedCollection is a wrapper around a Collection whose documents all decode into the Go type T. Methods that return
+// documents decode them into T instead of requiring callers to pass a value to Decode. It is safe for concurrent use by
+// multiple goroutines.
+type TypedCollection[T any] struct {
+	coll *Collection
+}
+
+// NewTypedCollection creates a TypedCollection for documents of type T backed by coll.
+func NewTypedCollection[T any](coll *Collection) *TypedCollection[T] {
+	return &TypedCollection[T]{coll: coll}
+}
+
+// Collection returns the untyped Collection backing this TypedCollection.
+func (tc *TypedCollection[T]) Collection() *Collection {
+	return tc.coll
+}
+
+// Name returns the name of the collection.
+func (tc *TypedCollection[T]) Name() string {
+	return tc.coll.Name()
+}
+
+// FindOne executes a find command and decodes one matching document into a T. If the filter does not match any
+// documents, the zero value of T and ErrNoDocuments are returned.
+//
+// See Collection.FindOne for a description of the filter and opts parameters.
+func (tc *TypedCollection[T]) FindOne(ctx context.Context, filter interface{},
+	opts ...*options.FindOneOptions) (T, error) {
+
+	return decodeSingleResult[T](tc.coll.FindOne(ctx, filter, opts...))
+}
+
+// Find executes a find command and returns a TypedCursor over the matching documents in the collection.
+//
+// See Collection.Find for a description of the filter and opts parameters.
+func (tc *TypedCollection[T]) Find(ctx context.Context, filter interface{},
+	opts ...*options.FindOptions) (*TypedCursor[T], error) {
+
+	cur, err := tc.coll.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewTypedCursor[T](cur), nil
+}
+
+// InsertOne executes an insert command to insert a single document into the collection.
+//
+// See Collection.InsertOne for a description of the opts parameter and how the _id field is handled.
+func (tc *TypedCollection[T]) InsertOne(ctx context.Context, document T,
+	opts ...*options.InsertOneOptions) (*InsertOneResult, error) {
+
+	return tc.coll.InsertOne(ctx, document, opts...)
+}
+
+// InsertMany executes an insert command to insert multiple documents into the collection. The documents slice cannot
+// be nil or empty.
+//
+// See Collection.InsertMany for a description of the opts parameter and the errors that can be returned.
+func (tc *TypedCollection[T]) InsertMany(ctx context.Context, documents []T,
+	opts ...*options.InsertManyOptions) (*InsertManyResult, error) {
+
+	docs := make([]interface{}, 0, len(documents))
+	for _, doc := range documents {
+		docs = append(docs, doc)
+	}
+	return tc.coll.InsertMany(ctx, docs, opts...)
+}
+
+// FindOneAndUpdate executes a findAndModify command to update at most one document in the collection and decodes the
+// returned document into a T. By default the document is returned as it appeared before updating; use the
+// ReturnDocument option to get the updated document instead. If the filter does not match any documents, the zero value
+// of T and ErrNoDocuments are returned.
+//
+// See Collection.FindOneAndUpdate for a description of the filter, update and opts parameters.
+func (tc *TypedCollection[T]) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{},
+	opts ...*options.FindOneAndUpdateOptions) (T, error) {
+
+	return decodeSingleResult[T](tc.coll.FindOneAndUpdate(ctx, filter, update, opts...))
+}
+
+// FindPage executes a find command and decodes one page of the matching documents into a []T. It also returns the
+// token of the next page, which is empty if this is the last page.
+//
+// See Collection.FindPage for a description of the filter, token and opts parameters.
+func (tc *TypedCollection[T]) FindPage(ctx context.Context, filter interface{}, token string,
+	opts ...*options.FindPageOptions) ([]T, string, error) {
+
+	page, err := tc.coll.FindPage(ctx, filter, token, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+	var results []T
+	if err := page.Decode(&results); err != nil {
+		return nil, "", err
+	}
+	return results, page.NextToken, nil
+}
+
+func decodeSingleResult[T any](sr *SingleResult) (T, error) {
+	var v T
+	if err := sr.Decode(&v); err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// TypedCursor is a wrapper around a Cursor that decodes each document into the Go type T. This type is not goroutine
+// safe and must not be used concurrently by multiple goroutines.
+type TypedCursor[T any] struct {
+	cur     *Cursor
+	current T
+	err     error
+}
+
+// NewTypedCursor creates a TypedCursor that decodes the documents returned by cur into values of type T.
+func NewTypedCursor[T any](cur *Cursor) *TypedCursor[T] {
+	return &TypedCursor[T]{cur: cur}
+}
+
+// Cursor returns the untyped Cursor backing this TypedCursor.
+func (tc *TypedCursor[T]) Cursor() *Cursor {
+	return tc.cur
+}
+
+// ID returns the ID of this cursor, or 0 if the cursor has been closed or exhausted.
+func (tc *TypedCursor[T]) ID() int64 { return tc.cur.ID() }
+
+// Next gets the next document for this cursor and decodes it into a T, which can be retrieved with Current. It returns
+// false if the cursor is exhausted or an error occurs while getting or decoding the document. If Next returns false,
+// subsequent calls will also return false.
+//
+// See Cursor.Next for details on blocking behavior.
+func (tc *TypedCursor[T]) Next(ctx context.Context) bool {
+	return tc.next(ctx, false)
+}
+
+// TryNext attempts to get the next document for this cursor and decode it into a T. It has the same semantics as
+// Cursor.TryNext, except that it also returns false if decoding fails.
+func (tc *TypedCursor[T]) TryNext(ctx context.Context) bool {
+	return tc.next(ctx, true)
+}
+
+func (tc *TypedCursor[T]) next(ctx context.Context, nonBlocking bool) bool {
+	if tc.err != nil {
+		return false
+	}
+
+	var ok bool
+	if nonBlocking {
+		ok = tc.cur.TryNext(ctx)
+	} else {
+		ok = tc.cur.Next(ctx)
+	}
+	if !ok {
+		return false
+	}
+
+	var v T
+	if err := tc.cur.Decode(&v); err != nil {
+		tc.err = err
+		return false
+	}
+	tc.current = v
+	return true
+}
+
+// Current returns the document decoded by the last successful call to Next or TryNext. If neither has returned true
+// yet, the zero value of T is returned.
+func (tc *TypedCursor[T]) Current() T {
+	return tc.current
+}
+
+// All iterates the cursor and decodes each remaining document into a T. This method will close the cursor after
+// retrieving all documents. If the cursor has been iterated, any previously iterated documents will not be included in
+// the result.
+func (tc *TypedCursor[T]) All(ctx context.Context) ([]T, error) {
+	if tc.err != nil {
+		_ = tc.cur.Close(context.Background())
+		return nil, tc.err
+	}
+
+	var results []T
+	if err := tc.cur.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Err returns the last error seen by the TypedCursor, including decoding errors, or nil if no error has occurred.
+func (tc *TypedCursor[T]) Err() error {
+	if tc.err != nil {
+		return tc.err
+	}
+	return tc.cur.Err()
+}
+
+// Close closes this cursor. Next and TryNext must not be called after Close has been called. Close is idempotent.
+func (tc *TypedCursor[T]) Close(ctx context.Context) error {
+	return tc.cur.Close(ctx)
+}
+
+// RemainingBatchLength returns the number of documents left in the current batch. If this returns zero, the subsequent
+// call to Next or TryNext will do a network request to fetch the next batch.
+func (tc *TypedCursor[T]) RemainingBatchLength() int {
+	return tc.cur.RemainingBatchLength()
+}
@@ -0,0 +1,506 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/writeconcern"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver/operation"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver/session"
+)
+
+// UserView is a type that can be used to create, modify, drop, and list the users and roles of a database. A UserView
+// for a database can be created by a call to Database.Users().
+//
+// The createUser, updateUser and usersInfo commands are redacted in command monitoring events and logs.
+type UserView struct {
+	db *Database
+}
+
+// RoleRef identifies a role by its name and the database it is defined in.
+type RoleRef struct {
+	Role string `bson:"role"`
+	DB   string `bson:"db"`
+}
+
+// AuthenticationRestriction restricts the client IP addresses and server addresses a user or role can authenticate
+// from and to.
+type AuthenticationRestriction struct {
+	ClientSource  []string `bson:"clientSource,omitempty"`
+	ServerAddress []string `bson:"serverAddress,omitempty"`
+}
+
+// PrivilegeResource is the resource a Privilege applies to. If Cluster is true, the resource is the cluster. If
+// AnyResource is true, the resource is every resource in the system. Otherwise, the resource is the collection named
+// Collection in the database named DB. An empty DB or Collection matches all databases or collections.
+type PrivilegeResource struct {
+	DB          string `bson:"db"`
+	Collection  string `bson:"collection"`
+	Cluster     bool   `bson:"cluster"`
+	AnyResource bool   `bson:"anyResource"`
+}
+
+// MarshalBSON implements the bson.Marshaler interface.
+func (pr PrivilegeResource) MarshalBSON() ([]byte, error) {
+	switch {
+	case pr.Cluster:
+		return bson.Marshal(bson.D{{"cluster", true}})
+	case pr.AnyResource:
+		return bson.Marshal(bson.D{{"anyResource", true}})
+	default:
+		return bson.Marshal(bson.D{{"db", pr.DB}, {"collection", pr.Collection}})
+	}
+}
+
+// Privilege is a set of actions allowed on a resource.
+type Privilege struct {
+	Resource PrivilegeResource `bson:"resource"`
+	Actions  []string          `bson:"actions"`
+}
+
+// User describes a user to be created by UserView.CreateUser.
+type User struct {
+	// The name of the user. It cannot be empty.
+	Name string
+
+	// The password of the user. This must be empty for users that authenticate externally, e.g. with x.509 or LDAP.
+	Password string
+
+	// The roles granted to the user. This can be empty to create a user without roles.
+	Roles []RoleRef
+
+	// Any information to be stored with the user. The default value is nil, which means that no custom data is stored.
+	CustomData interface{}
+
+	// The SCRAM mechanisms the user can authenticate with. The default value is nil, which means that the server
+	// default will be used.
+	Mechanisms []string
+
+	// The authentication restrictions of the user. The default value is nil, which means that there are none.
+	AuthenticationRestrictions []AuthenticationRestriction
+}
+
+// UserUpdate describes the changes to make to a user in UserView.UpdateUser. Fields that are nil are not changed.
+type UserUpdate struct {
+	// The new password of the user.
+	Password *string
+
+	// The roles granted to the user. If non-nil, this replaces all of the user's roles.
+	Roles []RoleRef
+
+	// The new custom data of the user.
+	CustomData interface{}
+
+	// The SCRAM mechanisms the user can authenticate with.
+	Mechanisms []string
+
+	// The authentication restrictions of the user. If non-nil, this replaces all of the user's restrictions.
+	AuthenticationRestrictions []AuthenticationRestriction
+}
+
+// UserInfo is the information about a user returned by UserView.UsersInfo.
+type UserInfo struct {
+	ID                         string                      `bson:"_id"`
+	Name                       string                      `bson:"user"`
+	DB                         string                      `bson:"db"`
+	Roles                      []RoleRef                   `bson:"roles"`
+	CustomData                 bson.Raw                    `bson:"customData"`
+	Mechanisms                 []string                    `bson:"mechanisms"`
+	AuthenticationRestrictions []AuthenticationRestriction `bson:"authenticationRestrictions"`
+
+	// The credentials of the user. This is only returned if the ShowCredentials option is set.
+	Credentials bson.Raw `bson:"credentials"`
+
+	// The roles and privileges inherited by the user. These are only returned if the ShowPrivileges option is set.
+	InheritedRoles      []RoleRef   `bson:"inheritedRoles"`
+	InheritedPrivileges []Privilege `bson:"inheritedPrivileges"`
+}
+
+// Role describes a role to be created by UserView.CreateRole.
+type Role struct {
+	// The name of the role. It cannot be empty.
+	Name string
+
+	// The privileges granted by the role. This can be empty to create a role without privileges.
+	Privileges []Privilege
+
+	// The roles the role inherits from. This can be empty to create a role that does not inherit from other roles.
+	Roles []RoleRef
+
+	// The authentication restrictions of the role. The default value is nil, which means that there are none.
+	AuthenticationRestrictions []AuthenticationRestriction
+}
+
+// RoleUpdate describes the changes to make to a role in UserView.UpdateRole. Fields that are nil are not changed;
+// fields that are non-nil replace the current value.
+type RoleUpdate struct {
+	Privileges                 []Privilege
+	Roles                      []RoleRef
+	AuthenticationRestrictions []AuthenticationRestriction
+}
+
+// RoleInfo is the information about a role returned by UserView.RolesInfo.
+type RoleInfo struct {
+	Name           string    `bson:"role"`
+	DB             string    `bson:"db"`
+	IsBuiltin      bool      `bson:"isBuiltin"`
+	Roles          []RoleRef `bson:"roles"`
+	InheritedRoles []RoleRef `bson:"inheritedRoles"`
+
+	// The privileges of the role. These are only returned if the ShowPrivileges option is set.
+	Privileges          []Privilege `bson:"privileges"`
+	InheritedPrivileges []Privilege `bson:"inheritedPrivileges"`
+}
+
+// CreateUser executes a createUser command to create a new user in the database.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/createUser/.
+func (uv UserView) CreateUser(ctx context.Context, user User) error {
+	if user.Name == "" {
+		return errors.New("user name must not be empty")
+	}
+
+	fields := bson.D{}
+	if user.Password != "" {
+		fields = append(fields, bson.E{"pwd", user.Password})
+	}
+	if user.CustomData != nil {
+		fields = append(fields, bson.E{"customData", user.CustomData})
+	}
+	fields = append(fields, bson.E{"roles", nonNilRoles(user.Roles)})
+	if user.Mechanisms != nil {
+		fields = append(fields, bson.E{"mechanisms", user.Mechanisms})
+	}
+	if user.AuthenticationRestrictions != nil {
+		fields = append(fields, bson.E{"authenticationRestrictions", user.AuthenticationRestrictions})
+	}
+
+	_, err := uv.run(ctx, "createUser", user.Name, fields, true)
+	return err
+}
+
+// UpdateUser executes an updateUser command to change the user with the given name.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/updateUser/.
+func (uv UserView) UpdateUser(ctx context.Context, name string, update UserUpdate) error {
+	fields := bson.D{}
+	if update.Password != nil {
+		fields = append(fields, bson.E{"pwd", *update.Password})
+	}
+	if update.CustomData != nil {
+		fields = append(fields, bson.E{"customData", update.CustomData})
+	}
+	if update.Roles != nil {
+		fields = append(fields, bson.E{"roles", update.Roles})
+	}
+	if update.Mechanisms != nil {
+		fields = append(fields, bson.E{"mechanisms", update.Mechanisms})
+	}
+	if update.AuthenticationRestrictions != nil {
+		fields = append(fields, bson.E{"authenticationRestrictions", update.AuthenticationRestrictions})
+	}
+	if len(fields) == 0 {
+		return errors.New("user update must change at least one field")
+	}
+
+	_, err := uv.run(ctx, "updateUser", name, fields, true)
+	return err
+}
+
+// DropUser executes a dropUser command to remove the user with the given name.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/dropUser/.
+func (uv UserView) DropUser(ctx context.Context, name string) error {
+	_, err := uv.run(ctx, "dropUser", name, nil, true)
+	return err
+}
+
+// GrantRoles executes a grantRolesToUser command to grant the given roles to the user with the given name.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/grantRolesToUser/.
+func (uv UserView) GrantRoles(ctx context.Context, name string, roles ...RoleRef) error {
+	if len(roles) == 0 {
+		return ErrEmptySlice
+	}
+	_, err := uv.run(ctx, "grantRolesToUser", name, bson.D{{"roles", roles}}, true)
+	return err
+}
+
+// RevokeRoles executes a revokeRolesFromUser command to revoke the given roles from the user with the given name.
+//
+// For more information about the command, see
+// https://www.mongodb.com/docs/manual/reference/command/revokeRolesFromUser/.
+func (uv UserView) RevokeRoles(ctx context.Context, name string, roles ...RoleRef) error {
+	if len(roles) == 0 {
+		return ErrEmptySlice
+	}
+	_, err := uv.run(ctx, "revokeRolesFromUser", name, bson.D{{"roles", roles}}, true)
+	return err
+}
+
+// UsersInfo executes a usersInfo command and returns the users with the given names. If no names are given, all users
+// of the database are returned.
+//
+// The opts parameter can be used to specify options for the operation (see the options.UsersInfoOptions
+// documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/usersInfo/.
+func (uv UserView) UsersInfo(ctx context.Context, names []string, opts ...*options.UsersInfoOptions) ([]UserInfo, error) {
+	var target interface{} = 1
+	if len(names) > 0 {
+		users := make(bson.A, 0, len(names))
+		for _, name := range names {
+			users = append(users, bson.D{{"user", name}, {"db", uv.db.name}})
+		}
+		target = users
+	}
+
+	uio := options.MergeUsersInfoOptions(opts...)
+	fields := bson.D{}
+	if uio.ShowCredentials != nil {
+		fields = append(fields, bson.E{"showCredentials", *uio.ShowCredentials})
+	}
+	if uio.ShowCustomData != nil {
+		fields = append(fields, bson.E{"showCustomData", *uio.ShowCustomData})
+	}
+	if uio.ShowPrivileges != nil {
+		fields = append(fields, bson.E{"showPrivileges", *uio.ShowPrivileges})
+	}
+	if uio.ShowAuthenticationRestrictions != nil {
+		fields = append(fields, bson.E{"showAuthenticationRestrictions", *uio.ShowAuthenticationRestrictions})
+	}
+
+	res, err := uv.run(ctx, "usersInfo", target, fields, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var out struct {
+		Users []UserInfo `bson:"users"`
+	}
+	if err = bson.UnmarshalWithRegistry(uv.db.registry, res, &out); err != nil {
+		return nil, err
+	}
+	return out.Users, nil
+}
+
+// CreateRole executes a createRole command to create a new role in the database.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/createRole/.
+func (uv UserView) CreateRole(ctx context.Context, role Role) error {
+	if role.Name == "" {
+		return errors.New("role name must not be empty")
+	}
+
+	privileges := role.Privileges
+	if privileges == nil {
+		privileges = []Privilege{}
+	}
+	fields := bson.D{
+		{"privileges", privileges},
+		{"roles", nonNilRoles(role.Roles)},
+	}
+	if role.AuthenticationRestrictions != nil {
+		fields = append(fields, bson.E{"authenticationRestrictions", role.AuthenticationRestrictions})
+	}
+
+	_, err := uv.run(ctx, "createRole", role.Name, fields, true)
+	return err
+}
+
+// UpdateRole executes an updateRole command to change the role with the given name.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/updateRole/.
+func (uv UserView) UpdateRole(ctx context.Context, name string, update RoleUpdate) error {
+	fields := bson.D{}
+	if update.Privileges != nil {
+		fields = append(fields, bson.E{"privileges", update.Privileges})
+	}
+	if update.Roles != nil {
+		fields = append(fields, bson.E{"roles", update.Roles})
+	}
+	if update.AuthenticationRestrictions != nil {
+		fields = append(fields, bson.E{"authenticationRestrictions", update.AuthenticationRestrictions})
+	}
+	if len(fields) == 0 {
+		return errors.New("role update must change at least one field")
+	}
+
+	_, err := uv.run(ctx, "updateRole", name, fields, true)
+	return err
+}
+
+// DropRole executes a dropRole command to remove the role with the given name.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/dropRole/.
+func (uv UserView) DropRole(ctx context.Context, name string) error {
+	_, err := uv.run(ctx, "dropRole", name, nil, true)
+	return err
+}
+
+// GrantRolesToRole executes a grantRolesToRole command to make the role with the given name inherit from the given
+// roles.
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/grantRolesToRole/.
+func (uv UserView) GrantRolesToRole(ctx context.Context, name string, roles ...RoleRef) error {
+	if len(roles) == 0 {
+		return ErrEmptySlice
+	}
+	_, err := uv.run(ctx, "grantRolesToRole", name, bson.D{{"roles", roles}}, true)
+	return err
+}
+
+// RevokeRolesFromRole executes a revokeRolesFromRole command to remove the given inherited roles from the role with
+// the given name.
+//
+// For more information about the command, see
+// https://www.mongodb.com/docs/manual/reference/command/revokeRolesFromRole/.
+func (uv UserView) RevokeRolesFromRole(ctx context.Context, name string, roles ...RoleRef) error {
+	if len(roles) == 0 {
+		return ErrEmptySlice
+	}
+	_, err := uv.run(ctx, "revokeRolesFromRole", name, bson.D{{"roles", roles}}, true)
+	return err
+}
+
+// GrantPrivileges executes a grantPrivilegesToRole command to grant the given privileges to the role with the given
+// name.
+//
+// For more information about the command, see
+// https://www.mongodb.com/docs/manual/reference/command/grantPrivilegesToRole/.
+func (uv UserView) GrantPrivileges(ctx context.Context, name string, privileges ...Privilege) error {
+	if len(privileges) == 0 {
+		return ErrEmptySlice
+	}
+	_, err := uv.run(ctx, "grantPrivilegesToRole", name, bson.D{{"privileges", privileges}}, true)
+	return err
+}
+
+// RevokePrivileges executes a revokePrivilegesFromRole command to revoke the given privileges from the role with the
+// given name.
+//
+// For more information about the command, see
+// https://www.mongodb.com/docs/manual/reference/command/revokePrivilegesFromRole/.
+func (uv UserView) RevokePrivileges(ctx context.Context, name string, privileges ...Privilege) error {
+	if len(privileges) == 0 {
+		return ErrEmptySlice
+	}
+	_, err := uv.run(ctx, "revokePrivilegesFromRole", name, bson.D{{"privileges", privileges}}, true)
+	return err
+}
+
+// RolesInfo executes a rolesInfo command and returns the roles with the given names. If no names are given, all
+// user-defined roles of the database are returned.
+//
+// The opts parameter can be used to specify options for the operation (see the options.RolesInfoOptions
+// documentation).
+//
+// For more information about the command, see https://www.mongodb.com/docs/manual/reference/command/rolesInfo/.
+func (uv UserView) RolesInfo(ctx context.Context, names []string, opts ...*options.RolesInfoOptions) ([]RoleInfo, error) {
+	var target interface{} = 1
+	if len(names) > 0 {
+		roles := make(bson.A, 0, len(names))
+		for _, name := range names {
+			roles = append(roles, bson.D{{"role", name}, {"db", uv.db.name}})
+		}
+		target = roles
+	}
+
+	rio := options.MergeRolesInfoOptions(opts...)
+	fields := bson.D{}
+	if rio.ShowPrivileges != nil {
+		fields = append(fields, bson.E{"showPrivileges", *rio.ShowPrivileges})
+	}
+	if rio.ShowBuiltinRoles != nil {
+		fields = append(fields, bson.E{"showBuiltinRoles", *rio.ShowBuiltinRoles})
+	}
+	if rio.ShowAuthenticationRestrictions != nil {
+		fields = append(fields, bson.E{"showAuthenticationRestrictions", *rio.ShowAuthenticationRestrictions})
+	}
+
+	res, err := uv.run(ctx, "rolesInfo", target, fields, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var out struct {
+		Roles []RoleInfo `bson:"roles"`
+	}
+	if err = bson.UnmarshalWithRegistry(uv.db.registry, res, &out); err != nil {
+		return nil, err
+	}
+	return out.Roles, nil
+}
+
+// run executes the user or role management command with the given name against the database. The database's write
+// concern is applied if write is true. The commands are always run against the primary.
+func (uv UserView) run(ctx context.Context, cmd string, target interface{}, fields bson.D,
+	write bool) (bsoncore.Document, error) {
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sess := sessionFromContext(ctx)
+	if sess == nil && uv.db.client.sessionPool != nil {
+		sess = session.NewImplicitClientSession(uv.db.client.sessionPool, uv.db.client.id)
+		defer sess.EndSession()
+	}
+
+	err := uv.db.client.validSession(sess)
+	if err != nil {
+		return nil, err
+	}
+
+	var wc *writeconcern.WriteConcern
+	if write {
+		wc = uv.db.writeConcern
+		if sess.TransactionRunning() {
+			wc = nil
+		}
+		if !writeconcern.AckWrite(wc) {
+			sess = nil
+		}
+	}
+
+	targetVal, err := marshalValue(target, uv.db.bsonOpts, uv.db.registry)
+	if err != nil {
+		return nil, err
+	}
+	var fieldsDoc bsoncore.Document
+	if len(fields) > 0 {
+		fieldsDoc, err = marshal(fields, uv.db.bsonOpts, uv.db.registry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	selector := makePinnedSelector(sess, uv.db.writeSelector)
+
+	op := operation.NewUserManagement(cmd, targetVal, fieldsDoc).
+		Session(sess).WriteConcern(wc).CommandMonitor(uv.db.client.monitor).Interceptors(uv.db.client.interceptors).
+		ServerSelector(selector).ClusterClock(uv.db.client.clock).
+		Database(uv.db.name).Deployment(uv.db.client.deployment).Crypt(uv.db.client.cryptFLE).
+		ServerAPI(uv.db.client.serverAPI).Timeout(uv.db.client.timeout).Logger(uv.db.client.logger)
+
+	if err = op.Execute(ctx); err != nil {
+		return nil, replaceErrors(err)
+	}
+	return op.Result(), nil
+}
+
+func nonNilRoles(roles []RoleRef) []RoleRef {
+	if roles == nil {
+		return []RoleRef{}
+	}
+	return roles
+}
@@ -0,0 +1,351 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/bsoncodec"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/options"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+)
+
+// VersionedCollection is a handle to a Collection that uses optimistic concurrency control. Every update and replace
+// matches the document only if its version field has the expected value and atomically increments the version field.
+// If no document matches, the operation returns a VersionConflictError. It is safe for concurrent use by multiple
+// goroutines.
+//
+// A document whose version field is missing is treated as having version 0. Upserts are not supported, because a
+// document with a stale version would not match and a new document would be inserted instead of reporting a conflict.
+type VersionedCollection struct {
+	coll  *Collection
+	field string
+}
+
+// errVersionedUpsert is returned by the methods of VersionedCollection if the upsert option is set.
+var errVersionedUpsert = errors.New("upsert is not supported by VersionedCollection")
+
+// Versioned creates a VersionedCollection for the collection that stores the version of each document in field.
+func (coll *Collection) Versioned(field string) *VersionedCollection {
+	return &VersionedCollection{coll: coll, field: field}
+}
+
+// VersionField returns the BSON name of the field of val that is marked with the mongoversion struct tag, e.g.
+//
+//	type Order struct {
+//		ID      primitive.ObjectID `bson:"_id"`
+//		Version int64              `bson:"_v" mongoversion:""`
+//	}
+//
+// The val parameter must be a struct or a pointer to a struct, and exactly one of its fields must have the tag.
+func VersionField(val interface{}) (string, error) {
+	sf, tags, err := versionStructField(reflect.TypeOf(val))
+	if err != nil {
+		return "", err
+	}
+	if sf == nil {
+		return "", fmt.Errorf("%T has no field with a mongoversion tag", val)
+	}
+	return tags.Name, nil
+}
+
+// Collection returns the Collection backing this VersionedCollection.
+func (vc *VersionedCollection) Collection() *Collection {
+	return vc.coll
+}
+
+// Field returns the name of the version field.
+func (vc *VersionedCollection) Field() string {
+	return vc.field
+}
+
+// ReplaceOne replaces the document matching filter whose version is the version held by replacement. The version is
+// read from the version field of replacement and the replacement is written with the version incremented by one. If
+// replacement is a pointer to a struct and the write succeeds, its version field is also incremented.
+//
+// See Collection.ReplaceOne for a description of the filter and opts parameters. The Upsert option must not be set.
+func (vc *VersionedCollection) ReplaceOne(ctx context.Context, filter interface{}, replacement interface{},
+	opts ...*options.ReplaceOptions) (*UpdateResult, error) {
+
+	if ro := options.MergeReplaceOptions(opts...); ro.Upsert != nil && *ro.Upsert {
+		return nil, errVersionedUpsert
+	}
+
+	doc, err := marshal(replacement, vc.coll.bsonOpts, vc.coll.registry)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureNoDollarKey(doc); err != nil {
+		return nil, err
+	}
+
+	version, err := vc.documentVersion(doc)
+	if err != nil {
+		return nil, err
+	}
+	f, err := vc.versionedFilter(filter, version)
+	if err != nil {
+		return nil, err
+	}
+	doc, err = vc.setDocumentVersion(doc, version+1)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := vc.coll.ReplaceOne(ctx, f, bson.Raw(doc), opts...)
+	if err != nil {
+		return res, err
+	}
+	if res.MatchedCount == 0 {
+		return res, VersionConflictError{Field: vc.field, Version: version}
+	}
+	setStructVersion(replacement, vc.field, version+1)
+	return res, nil
+}
+
+// UpdateOne updates the document matching filter whose version is version and increments its version field by one.
+// The update parameter must be a document containing update operators and must not modify the version field.
+//
+// See Collection.UpdateOne for a description of the filter, update and opts parameters. The Upsert option must not be
+// set.
+func (vc *VersionedCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, version int64,
+	opts ...*options.UpdateOptions) (*UpdateResult, error) {
+
+	if uo := options.MergeUpdateOptions(opts...); uo.Upsert != nil && *uo.Upsert {
+		return nil, errVersionedUpsert
+	}
+
+	f, err := vc.versionedFilter(filter, version)
+	if err != nil {
+		return nil, err
+	}
+	u, err := vc.versionedUpdate(update)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := vc.coll.UpdateOne(ctx, f, u, opts...)
+	if err != nil {
+		return res, err
+	}
+	if res.MatchedCount == 0 {
+		return res, VersionConflictError{Field: vc.field, Version: version}
+	}
+	return res, nil
+}
+
+// FindOneAndUpdate updates the document matching filter whose version is version, increments its version field by one
+// and returns the document. If no document matches, the returned SingleResult holds a VersionConflictError instead of
+// ErrNoDocuments. The update parameter must be a document containing update operators and must not modify the version
+// field.
+//
+// See Collection.FindOneAndUpdate for a description of the filter, update and opts parameters. The Upsert option
+// must not be set.
+func (vc *VersionedCollection) FindOneAndUpdate(ctx context.Context, filter interface{}, update interface{},
+	version int64, opts ...*options.FindOneAndUpdateOptions) *SingleResult {
+
+	if fo := options.MergeFindOneAndUpdateOptions(opts...); fo.Upsert != nil && *fo.Upsert {
+		return &SingleResult{err: errVersionedUpsert}
+	}
+
+	f, err := vc.versionedFilter(filter, version)
+	if err != nil {
+		return &SingleResult{err: err}
+	}
+	u, err := vc.versionedUpdate(update)
+	if err != nil {
+		return &SingleResult{err: err}
+	}
+
+	sr := vc.coll.FindOneAndUpdate(ctx, f, u, opts...)
+	if errors.Is(sr.err, ErrNoDocuments) {
+		sr.err = VersionConflictError{Field: vc.field, Version: version}
+	}
+	return sr
+}
+
+// versionedFilter returns filter with an added condition that the version field equals version. Version 0 also
+// matches documents without the version field.
+func (vc *VersionedCollection) versionedFilter(filter interface{}, version int64) (bson.Raw, error) {
+	f, err := marshal(filter, vc.coll.bsonOpts, vc.coll.registry)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.LookupErr(vc.field); err == nil {
+		return nil, fmt.Errorf("filter must not contain the version field %q", vc.field)
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	elems, err := f.Elements()
+	if err != nil {
+		return nil, err
+	}
+	for _, elem := range elems {
+		doc = append(doc, elem...)
+	}
+	if version == 0 {
+		var aidx, didx int32
+		didx, doc = bsoncore.AppendDocumentElementStart(doc, vc.field)
+		aidx, doc = bsoncore.AppendArrayElementStart(doc, "$in")
+		doc = bsoncore.AppendInt64Element(doc, "0", 0)
+		doc = bsoncore.AppendNullElement(doc, "1")
+		doc, _ = bsoncore.AppendArrayEnd(doc, aidx)
+		doc, _ = bsoncore.AppendDocumentEnd(doc, didx)
+	} else {
+		doc = bsoncore.AppendInt64Element(doc, vc.field, version)
+	}
+	doc, err = bsoncore.AppendDocumentEnd(doc, idx)
+	return bson.Raw(doc), err
+}
+
+// versionedUpdate returns the update document with an added $inc of the version field.
+func (vc *VersionedCollection) versionedUpdate(update interface{}) (bson.Raw, error) {
+	u, err := marshal(update, vc.coll.bsonOpts, vc.coll.registry)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureDollarKey(u); err != nil {
+		return nil, err
+	}
+	elems, err := u.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	incremented := false
+	for _, elem := range elems {
+		fields, ok := elem.Value().DocumentOK()
+		if !ok {
+			return nil, fmt.Errorf("update operator %s must be a document", elem.Key())
+		}
+		if _, err := fields.LookupErr(vc.field); err == nil {
+			return nil, fmt.Errorf("update must not modify the version field %q", vc.field)
+		}
+		if elem.Key() != "$inc" {
+			doc = append(doc, elem...)
+			continue
+		}
+
+		var iidx int32
+		iidx, doc = bsoncore.AppendDocumentElementStart(doc, "$inc")
+		fieldElems, err := fields.Elements()
+		if err != nil {
+			return nil, err
+		}
+		for _, fe := range fieldElems {
+			doc = append(doc, fe...)
+		}
+		doc = bsoncore.AppendInt64Element(doc, vc.field, 1)
+		doc, _ = bsoncore.AppendDocumentEnd(doc, iidx)
+		incremented = true
+	}
+	if !incremented {
+		doc = bsoncore.AppendDocumentElement(doc, "$inc", bsoncore.NewDocumentBuilder().
+			AppendInt64(vc.field, 1).Build())
+	}
+	doc, err = bsoncore.AppendDocumentEnd(doc, idx)
+	return bson.Raw(doc), err
+}
+
+// documentVersion returns the value of the version field in doc, or 0 if doc has no version field.
+func (vc *VersionedCollection) documentVersion(doc bsoncore.Document) (int64, error) {
+	val, err := doc.LookupErr(vc.field)
+	if err != nil {
+		return 0, nil
+	}
+	if val.Type == bson.TypeNull {
+		return 0, nil
+	}
+	version, ok := val.AsInt64OK()
+	if !ok {
+		return 0, fmt.Errorf("version field %q must be an integer, but was of type %s", vc.field, val.Type)
+	}
+	return version, nil
+}
+
+// setDocumentVersion returns a copy of doc with the version field set to version.
+func (vc *VersionedCollection) setDocumentVersion(doc bsoncore.Document, version int64) (bsoncore.Document, error) {
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, out := bsoncore.AppendDocumentStart(nil)
+	set := false
+	for _, elem := range elems {
+		if elem.Key() == vc.field {
+			out = bsoncore.AppendInt64Element(out, vc.field, version)
+			set = true
+			continue
+		}
+		out = append(out, elem...)
+	}
+	if !set {
+		out = bsoncore.AppendInt64Element(out, vc.field, version)
+	}
+	return bsoncore.AppendDocumentEnd(out, idx)
+}
+
+// versionStructField returns the field of the struct type t with a mongoversion tag, or nil if there is none.
+func versionStructField(t reflect.Type) (*reflect.StructField, bsoncodec.StructTags, error) {
+	if t == nil {
+		return nil, bsoncodec.StructTags{}, errors.New("version struct value must not be nil")
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, bsoncodec.StructTags{}, fmt.Errorf("version struct value must be a struct, but was %s", t)
+	}
+
+	var found *reflect.StructField
+	var foundTags bsoncodec.StructTags
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if _, ok := sf.Tag.Lookup("mongoversion"); !ok {
+			continue
+		}
+		if found != nil {
+			return nil, bsoncodec.StructTags{}, fmt.Errorf("%s has more than one field with a mongoversion tag", t)
+		}
+		tags, err := bsoncodec.DefaultStructTagParser.ParseStructTags(sf)
+		if err != nil {
+			return nil, bsoncodec.StructTags{}, err
+		}
+		found, foundTags = &sf, tags
+	}
+	return found, foundTags, nil
+}
+
+// setStructVersion sets the version field of val to version if val is a pointer to a struct whose version field is
+// named field.
+func setStructVersion(val interface{}, field string, version int64) {
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return
+	}
+	sf, tags, err := versionStructField(rv.Type())
+	if err != nil || sf == nil || tags.Name != field {
+		return
+	}
+
+	fv := rv.Elem().FieldByIndex(sf.Index)
+	if !fv.CanSet() {
+		return
+	}
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fv.SetInt(version)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fv.SetUint(uint64(version))
+	}
+}
@@ -48,9 +48,12 @@ type BatchCursor struct {
 	currentBatch         *bsoncore.DocumentSequence
 	firstBatch           bool
 	cmdMonitor           *event.CommandMonitor
+	interceptors         []event.CommandInterceptor
 	postBatchResumeToken bsoncore.Document
 	crypt                Crypt
 	serverAPI            *ServerAPIOptions
+	prefetchBatches      int32
+	prefetch             *batchPrefetcher
 
 	// legacy server (< 3.2) fields
 	limit       int32
@@ -157,9 +160,15 @@ type CursorOptions struct {
 	MaxTimeMS             int64
 	Limit                 int32
 	CommandMonitor        *event.CommandMonitor
+	Interceptors          []event.CommandInterceptor
 	Crypt                 Crypt
 	ServerAPI             *ServerAPIOptions
 	MarshalValueEncoderFn func(io.Writer) (*bson.Encoder, error)
+
+	// PrefetchBatches is the maximum number of batches to fetch in the background ahead of the
+	// batch being processed. Prefetching is disabled if it is 0 or if the cursor uses an explicit
+	// session.
+	PrefetchBatches int32
 }
 
 // NewBatchCursor creates a new BatchCursor from the provided parameters.
@@ -178,12 +187,14 @@ func NewBatchCursor(cr CursorResponse, clientSession *session.Client, clock *ses
 		batchSize:            opts.BatchSize,
 		maxTimeMS:            opts.MaxTimeMS,
 		cmdMonitor:           opts.CommandMonitor,
+		interceptors:         opts.Interceptors,
 		firstBatch:           true,
 		postBatchResumeToken: cr.postBatchResumeToken,
 		crypt:                opts.Crypt,
 		serverAPI:            opts.ServerAPI,
 		serverDescription:    cr.Desc,
 		encoderFn:            opts.MarshalValueEncoderFn,
+		prefetchBatches:      opts.PrefetchBatches,
 	}
 
 	if ds != nil {
@@ -246,6 +257,7 @@ func (bc *BatchCursor) Next(ctx context.Context) bool {
 
 	if bc.firstBatch {
 		bc.firstBatch = false
+		bc.startPrefetch(ctx)
 		return !bc.currentBatch.Empty()
 	}
 
@@ -253,6 +265,10 @@ func (bc *BatchCursor) Next(ctx context.Context) bool {
 		return false
 	}
 
+	if bc.prefetch != nil {
+		return bc.nextPrefetched(ctx)
+	}
+
 	bc.getMore(ctx)
 
 	return !bc.currentBatch.Empty()
@@ -271,6 +287,7 @@ func (bc *BatchCursor) Close(ctx context.Context) error {
 		ctx = context.Background()
 	}
 
+	bc.stopPrefetch()
 	err := bc.KillCursor(ctx)
 	bc.id = 0
 	bc.currentBatch.Data = nil
@@ -325,6 +342,7 @@ func (bc *BatchCursor) KillCursor(ctx context.Context) error {
 		Clock:          bc.clock,
 		Legacy:         LegacyKillCursors,
 		CommandMonitor: bc.cmdMonitor,
+		Interceptors:   bc.interceptors,
 		ServerAPI:      bc.serverAPI,
 
 		// No read preference is passed to the killCursor command,
@@ -430,6 +448,7 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 		Clock:          bc.clock,
 		Legacy:         LegacyGetMore,
 		CommandMonitor: bc.cmdMonitor,
+		Interceptors:   bc.interceptors,
 		Crypt:          bc.crypt,
 		ServerAPI:      bc.serverAPI,
 
@@ -0,0 +1,146 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package driver
+
+import (
+	"context"
+	"time"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+)
+
+// batchPrefetcher runs getMore commands for a BatchCursor on a background goroutine. The goroutine works on its own
+// copy of the cursor state, and each batch it fetches is handed to the BatchCursor together with the cursor state
+// after the getMore.
+type batchPrefetcher struct {
+	results chan prefetchedBatch
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	// timeout is the time limit of each getMore, or 0 if there is none.
+	timeout time.Duration
+
+	// fetcher is owned by the goroutine until done is closed.
+	fetcher *BatchCursor
+}
+
+// prefetchedBatch is a batch fetched by a batchPrefetcher and the cursor state after fetching it.
+type prefetchedBatch struct {
+	batch                *bsoncore.DocumentSequence
+	id                   int64
+	numReturned          int32
+	postBatchResumeToken bsoncore.Document
+	connection           PinnedConnection
+	err                  error
+}
+
+// startPrefetch starts fetching batches in the background if prefetching is enabled and the cursor has more batches.
+// The getMore commands use the batch size, maxTimeMS and comment of the cursor at the time prefetching starts.
+//
+// The getMore commands outlive the Next call that starts prefetching, so they run with the values of its context but
+// are only cancelled by stopPrefetch. If ctx has a deadline, each getMore is limited to the time that ctx had left.
+func (bc *BatchCursor) startPrefetch(ctx context.Context) {
+	if bc.prefetchBatches <= 0 || bc.prefetch != nil || bc.id == 0 || bc.server == nil {
+		return
+	}
+	// A session is not safe for concurrent use, so only prefetch if the session is owned by the cursor.
+	if bc.clientSession != nil && !bc.clientSession.IsImplicit {
+		return
+	}
+
+	var timeout time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		if timeout = time.Until(deadline); timeout <= 0 {
+			return
+		}
+	}
+
+	fetcher := *bc
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	bc.prefetch = &batchPrefetcher{
+		// The goroutine holds one batch while it waits to send it, so the channel buffers one batch less than the
+		// limit.
+		results: make(chan prefetchedBatch, bc.prefetchBatches-1),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		timeout: timeout,
+		fetcher: &fetcher,
+	}
+	go bc.prefetch.run(ctx)
+}
+
+// run fetches batches until the cursor is exhausted, a getMore fails or ctx is cancelled.
+func (p *batchPrefetcher) run(ctx context.Context) {
+	defer close(p.done)
+	defer close(p.results)
+
+	f := p.fetcher
+	for f.id != 0 && f.err == nil {
+		// getMore reuses the buffer of the current batch, so each batch gets a new sequence.
+		f.currentBatch = new(bsoncore.DocumentSequence)
+		getMoreCtx, cancel := ctx, context.CancelFunc(func() {})
+		if p.timeout > 0 {
+			getMoreCtx, cancel = context.WithTimeout(ctx, p.timeout)
+		}
+		f.getMore(getMoreCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		res := prefetchedBatch{
+			batch:                f.currentBatch,
+			id:                   f.id,
+			numReturned:          f.numReturned,
+			postBatchResumeToken: f.postBatchResumeToken,
+			connection:           f.connection,
+			err:                  f.err,
+		}
+		select {
+		case p.results <- res:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// nextPrefetched waits for the next prefetched batch and makes it the current batch.
+func (bc *BatchCursor) nextPrefetched(ctx context.Context) bool {
+	select {
+	case res, ok := <-bc.prefetch.results:
+		if !ok {
+			return false
+		}
+		bc.currentBatch.Data = res.batch.Data
+		bc.currentBatch.Style = res.batch.Style
+		bc.currentBatch.ResetIterator()
+		bc.id = res.id
+		bc.numReturned = res.numReturned
+		bc.postBatchResumeToken = res.postBatchResumeToken
+		bc.connection = res.connection
+		bc.err = res.err
+		return !bc.currentBatch.Empty()
+	case <-ctx.Done():
+		bc.err = ctx.Err()
+		return false
+	}
+}
+
+// stopPrefetch cancels the background goroutine, waits for it to exit and takes over the cursor ID and pinned
+// connection from it so that Close can clean them up. Batches that were fetched but not consumed are discarded.
+func (bc *BatchCursor) stopPrefetch() {
+	p := bc.prefetch
+	if p == nil {
+		return
+	}
+	bc.prefetch = nil
+
+	p.cancel()
+	<-p.done
+	bc.id = p.fetcher.id
+	bc.connection = p.fetcher.connection
+}
@@ -8,6 +8,7 @@ package driver
 
 import (
 	"errors"
+	"strconv"
 
 	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
 )
@@ -16,6 +17,24 @@ import (
 // server is passed to an insert command.
 var ErrDocumentTooLarge = errors.New("an inserted document is too large")
 
+// DocumentSequence is an OP_MSG document sequence section. The documents are sent after the command
+// document under the given identifier instead of as an array within the command.
+type DocumentSequence struct {
+	Identifier string
+	Documents  []bsoncore.Document
+}
+
+// appendArray appends the documents in the sequence to dst as an array element. This is used to
+// include the sequence in the command reported to command monitors.
+func (ds DocumentSequence) appendArray(dst []byte) []byte {
+	aidx, dst := bsoncore.AppendArrayElementStart(dst, ds.Identifier)
+	for i, doc := range ds.Documents {
+		dst = bsoncore.AppendDocumentElement(dst, strconv.Itoa(i), doc)
+	}
+	dst, _ = bsoncore.AppendArrayEnd(dst, aidx)
+	return dst
+}
+
 // Batches contains the necessary information to batch split an operation. This is only used for write
 // operations.
 type Batches struct {
@@ -0,0 +1,225 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package driver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson"
+	"github.com/zhangdapeng520/zdpgo_mongo/event"
+	"github.com/zhangdapeng520/zdpgo_mongo/internal/csot"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/description"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver/wiremessage"
+)
+
+// selectedConnection is a server and connection selected for the first attempt of an intercepted operation, so that
+// the command passed to the interceptors is built for the server that it is sent to.
+type selectedConnection struct {
+	server Server
+	conn   Connection
+}
+
+// take returns the selected server and connection and clears them, so that they are only used once.
+func (sc *selectedConnection) take() (Server, Connection) {
+	srvr, conn := sc.server, sc.conn
+	sc.server, sc.conn = nil, nil
+	return srvr, conn
+}
+
+// executeIntercepted selects a server for this operation, builds its command for that server and runs it through the
+// interceptors of the operation.
+func (op Operation) executeIntercepted(ctx context.Context) error {
+	if err := op.Validate(); err != nil {
+		return err
+	}
+
+	// The timeout must also cover server selection, which happens before execute is called.
+	if op.Timeout != nil && !csot.IsTimeoutContext(ctx) {
+		newCtx, cancelFunc := csot.MakeTimeoutContext(ctx, *op.Timeout)
+		ctx = newCtx
+		defer cancelFunc()
+	}
+
+	if op.Client != nil {
+		if err := op.Client.StartCommand(); err != nil {
+			return err
+		}
+	}
+
+	srvr, conn, err := op.getServerAndConnection(ctx, wiremessage.NextRequestID(), nil)
+	if err != nil {
+		return err
+	}
+	selected := &selectedConnection{server: srvr, conn: conn}
+	defer func() {
+		// The connection is not used if an interceptor rejects the operation.
+		if _, conn := selected.take(); conn != nil {
+			_ = conn.Close()
+		}
+	}()
+
+	desc := description.SelectedServer{Server: conn.Description(), Kind: op.Deployment.Kind()}
+	cidx, command := bsoncore.AppendDocumentStart(nil)
+	command, err = op.CommandFn(command, desc)
+	if err != nil {
+		return err
+	}
+	command, _ = bsoncore.AppendDocumentEnd(command, cidx)
+	if len(command) <= 5 {
+		return errors.New("operation built an empty command")
+	}
+
+	cmd := &event.InterceptedCommand{
+		DatabaseName: op.Database,
+		CommandName:  op.getCommandName(command),
+		Command:      bson.Raw(command),
+	}
+	handler := func(ctx context.Context, cmd *event.InterceptedCommand) error {
+		return op.executeInterceptedCommand(ctx, selected, command, cmd)
+	}
+	for i := len(op.Interceptors) - 1; i >= 0; i-- {
+		interceptor, next := op.Interceptors[i], handler
+		if interceptor == nil {
+			continue
+		}
+		handler = func(ctx context.Context, cmd *event.InterceptedCommand) error {
+			return interceptor(ctx, cmd, next)
+		}
+	}
+	return handler(ctx, cmd)
+}
+
+// executeInterceptedCommand runs this operation with the changes that the interceptors made to its command and
+// records the server response in cmd. The first attempt uses the selected connection, if it has not been used yet, and
+// sends the command passed to the interceptors without building it again. Later attempts rebuild the command for their
+// server and apply the same changes.
+func (op Operation) executeInterceptedCommand(ctx context.Context, selected *selectedConnection,
+	command bsoncore.Document, cmd *event.InterceptedCommand) error {
+
+	edited := bsoncore.Document(cmd.Command)
+	var edits commandEdits
+	if !bytes.Equal(edited, command) {
+		var err error
+		edits, err = diffCommand(command, edited)
+		if err != nil {
+			return err
+		}
+		if _, err := edited.LookupErr("maxTimeMS"); err == nil {
+			op.omitMaxTimeMS = true
+		}
+	}
+
+	reuse := selected.conn != nil
+	op.selected = selected
+	commandFn := op.CommandFn
+	op.CommandFn = func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+		if reuse {
+			reuse = false
+			return append(dst, edited[4:len(edited)-1]...), nil
+		}
+		start := len(dst)
+		dst, err := commandFn(dst, desc)
+		if err != nil || edits.empty() {
+			return dst, err
+		}
+		return edits.apply(dst, start)
+	}
+
+	processResponseFn := op.ProcessResponseFn
+	op.ProcessResponseFn = func(info ResponseInfo) error {
+		cmd.Reply = bson.Raw(info.ServerResponse)
+		if processResponseFn == nil {
+			return nil
+		}
+		return processResponseFn(info)
+	}
+	return op.execute(ctx)
+}
+
+// commandEdits are the changes an interceptor made to the elements of a command.
+type commandEdits struct {
+	set    []bsoncore.Element
+	remove map[string]bool
+}
+
+// empty returns true if there are no changes.
+func (ce commandEdits) empty() bool {
+	return len(ce.set) == 0 && len(ce.remove) == 0
+}
+
+// diffCommand returns the changes needed to turn the command elements in original into the ones in edited.
+func diffCommand(original, edited bsoncore.Document) (commandEdits, error) {
+	if err := edited.Validate(); err != nil {
+		return commandEdits{}, fmt.Errorf("intercepted command is not a valid document: %w", err)
+	}
+	originalElems, err := original.Elements()
+	if err != nil {
+		return commandEdits{}, err
+	}
+	editedElems, err := edited.Elements()
+	if err != nil {
+		return commandEdits{}, err
+	}
+	if len(editedElems) == 0 || len(originalElems) == 0 || editedElems[0].Key() != originalElems[0].Key() {
+		return commandEdits{}, errors.New("intercepted command must start with the original command name")
+	}
+
+	edits := commandEdits{remove: make(map[string]bool)}
+	for _, elem := range editedElems {
+		orig, err := original.LookupErr(elem.Key())
+		if err != nil || orig.Type != elem.Value().Type || !bytes.Equal(orig.Data, elem.Value().Data) {
+			edits.set = append(edits.set, elem)
+		}
+	}
+	for _, elem := range originalElems {
+		if _, err := edited.LookupErr(elem.Key()); err != nil {
+			edits.remove[elem.Key()] = true
+		}
+	}
+	return edits, nil
+}
+
+// apply applies the edits to the command elements that start at dst[start:]. Changed elements keep their position and
+// added elements are appended.
+func (ce commandEdits) apply(dst []byte, start int) ([]byte, error) {
+	rem := append([]byte(nil), dst[start:]...)
+	dst = dst[:start]
+	applied := make([]bool, len(ce.set))
+	for len(rem) > 0 {
+		elem, next, ok := bsoncore.ReadElement(rem)
+		if !ok {
+			return dst, errors.New("malformed command element")
+		}
+		rem = next
+
+		key := elem.Key()
+		if ce.remove[key] {
+			continue
+		}
+		replaced := false
+		for i, set := range ce.set {
+			if set.Key() == key {
+				dst = append(dst, set...)
+				applied[i], replaced = true, true
+				break
+			}
+		}
+		if !replaced {
+			dst = append(dst, elem...)
+		}
+	}
+	for i, set := range ce.set {
+		if !applied[i] {
+			dst = append(dst, set...)
+		}
+	}
+	return dst, nil
+}
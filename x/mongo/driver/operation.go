@@ -96,6 +96,7 @@ type startedInformation struct {
 	requestID                int32
 	cmdName                  string
 	documentSequenceIncluded bool
+	documentSequences        []DocumentSequence
 	connID                   string
 	driverConnectionID       uint64 // TODO(GODRIVER-2824): change type to int64.
 	serverConnID             *int64
@@ -176,6 +177,13 @@ func redactStartedInformationCmd(op Operation, info startedInformation) bson.Raw
 			// add back 0 byte and update length
 			cmdCopy, _ = bsoncore.AppendDocumentEnd(cmdCopy, 0)
 		}
+		if len(info.documentSequences) > 0 {
+			cmdCopy = cmdCopy[:len(cmdCopy)-1]
+			for _, seq := range info.documentSequences {
+				cmdCopy = seq.appendArray(cmdCopy)
+			}
+			cmdCopy, _ = bsoncore.AppendDocumentEnd(cmdCopy, 0)
+		}
 	}
 
 	return cmdCopy
@@ -278,6 +286,12 @@ type Operation struct {
 	// Batches.
 	Batches *Batches
 
+	// DocumentSequencesFn is used to create OP_MSG document sequences that are sent alongside the
+	// command document. It is intended for commands, such as the client-level bulkWrite command,
+	// that send more than one document sequence and split batches themselves. This field cannot be
+	// used together with Batches or automatic encryption.
+	DocumentSequencesFn func(desc description.SelectedServer) ([]DocumentSequence, error)
+
 	// Legacy sets the legacy type for this operation. There are only 3 types that require legacy
 	// support: find, getMore, and killCursors. For more information about LegacyOperationKind,
 	// please refer to it's definition.
@@ -287,6 +301,11 @@ type Operation struct {
 	// no events will be reported.
 	CommandMonitor *event.CommandMonitor
 
+	// Interceptors specifies the interceptors that wrap the execution of the operation. The first
+	// interceptor is the outermost one. If this field is not set, the operation is executed
+	// directly.
+	Interceptors []event.CommandInterceptor
+
 	// Crypt specifies a Crypt object to use for automatic client side encryption and decryption.
 	Crypt Crypt
 
@@ -320,6 +339,16 @@ type Operation struct {
 	// where a default read preference is used when the operation
 	// ReadPreference is not specified.
 	omitReadPreference bool
+
+	// omitMaxTimeMS is a boolean that indicates whether to omit the "maxTimeMS"
+	// calculated by the driver because an interceptor has added "maxTimeMS"
+	// to the command.
+	omitMaxTimeMS bool
+
+	// selected is the server and connection selected for the first attempt of an
+	// intercepted operation. If it is set and has not been used yet, it is used
+	// instead of selecting a server.
+	selected *selectedConnection
 }
 
 // shouldEncrypt returns true if this operation should automatically be encrypted.
@@ -485,6 +514,9 @@ func (op Operation) Validate() error {
 	if op.Client != nil && !writeconcern.AckWrite(op.WriteConcern) {
 		return errors.New("session provided for an unacknowledged write")
 	}
+	if op.Batches != nil && op.DocumentSequencesFn != nil {
+		return errors.New("Batches and DocumentSequencesFn cannot both be set")
+	}
 	return nil
 }
 
@@ -499,6 +531,14 @@ var memoryPool = sync.Pool{
 
 // Execute runs this operation.
 func (op Operation) Execute(ctx context.Context) error {
+	if len(op.Interceptors) > 0 {
+		return op.executeIntercepted(ctx)
+	}
+	return op.execute(ctx)
+}
+
+// execute runs this operation without calling the interceptors.
+func (op Operation) execute(ctx context.Context) error {
 	err := op.Validate()
 	if err != nil {
 		return err
@@ -633,8 +673,9 @@ func (op Operation) Execute(ctx context.Context) error {
 
 		// If the server or connection are nil, try to select a new server and get a new connection.
 		if srvr == nil || conn == nil {
-			srvr, conn, err = op.getServerAndConnection(ctx, requestID, deprioritizedServers)
-			if err != nil {
+			if op.selected != nil && op.selected.conn != nil {
+				srvr, conn = op.selected.take()
+			} else if srvr, conn, err = op.getServerAndConnection(ctx, requestID, deprioritizedServers); err != nil {
 				// If the returned error is retryable and there are retries remaining (negative
 				// retries means retry indefinitely), then retry the operation. Set the server
 				// and connection to nil to request a new server and connection.
@@ -1260,7 +1301,7 @@ func (op Operation) createLegacyHandshakeWireMessage(
 	dst = op.addServerAPI(dst)
 	// If maxTimeMS is greater than 0 append it to wire message. A maxTimeMS value of 0 only explicitly
 	// specifies the default behavior of no timeout server-side.
-	if maxTimeMS > 0 {
+	if maxTimeMS > 0 && !op.omitMaxTimeMS {
 		dst = bsoncore.AppendInt64Element(dst, "maxTimeMS", int64(maxTimeMS))
 	}
 
@@ -1331,7 +1372,7 @@ func (op Operation) createMsgWireMessage(
 	dst = op.addServerAPI(dst)
 	// If maxTimeMS is greater than 0 append it to wire message. A maxTimeMS value of 0 only explicitly
 	// specifies the default behavior of no timeout server-side.
-	if maxTimeMS > 0 {
+	if maxTimeMS > 0 && !op.omitMaxTimeMS {
 		dst = bsoncore.AppendInt64Element(dst, "maxTimeMS", int64(maxTimeMS))
 	}
 
@@ -1365,6 +1406,30 @@ func (op Operation) createMsgWireMessage(
 		dst = bsoncore.UpdateLength(dst, idx, int32(len(dst[idx:])))
 	}
 
+	if op.DocumentSequencesFn != nil {
+		if op.shouldEncrypt() {
+			return dst, info, errors.New("document sequences are not supported with auto-encryption")
+		}
+		seqs, err := op.DocumentSequencesFn(desc)
+		if err != nil {
+			return dst, info, err
+		}
+		for _, seq := range seqs {
+			dst = wiremessage.AppendMsgSectionType(dst, wiremessage.DocumentSequence)
+			idx, dst = bsoncore.ReserveLength(dst)
+
+			dst = append(dst, seq.Identifier...)
+			dst = append(dst, 0x00)
+
+			for _, doc := range seq.Documents {
+				dst = append(dst, doc...)
+			}
+
+			dst = bsoncore.UpdateLength(dst, idx, int32(len(dst[idx:])))
+		}
+		info.documentSequences = seqs
+	}
+
 	return bsoncore.UpdateLength(dst, wmindex, int32(len(dst[wmindex:]))), info, nil
 }
 
@@ -1932,6 +1997,10 @@ func (op *Operation) redactCommand(cmd string, doc bsoncore.Document) bool {
 
 		return true
 	}
+	// The reply to usersInfo contains the credentials of the users if showCredentials is set.
+	if cmd == "usersInfo" {
+		return true
+	}
 	if strings.ToLower(cmd) != handshake.LegacyHelloLowercase && cmd != "hello" {
 		return false
 	}
@@ -26,6 +26,7 @@ type AbortTransaction struct {
 	clock         *session.ClusterClock
 	collection    string
 	monitor       *event.CommandMonitor
+	interceptors  []event.CommandInterceptor
 	crypt         driver.Crypt
 	database      string
 	deployment    driver.Deployment
@@ -59,6 +60,7 @@ func (at *AbortTransaction) Execute(ctx context.Context) error {
 		Client:            at.session,
 		Clock:             at.clock,
 		CommandMonitor:    at.monitor,
+		Interceptors:      at.interceptors,
 		Crypt:             at.crypt,
 		Database:          at.database,
 		Deployment:        at.deployment,
@@ -129,6 +131,16 @@ func (at *AbortTransaction) CommandMonitor(monitor *event.CommandMonitor) *Abort
 	return at
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (at *AbortTransaction) Interceptors(interceptors []event.CommandInterceptor) *AbortTransaction {
+	if at == nil {
+		at = new(AbortTransaction)
+	}
+
+	at.interceptors = interceptors
+	return at
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (at *AbortTransaction) Crypt(crypt driver.Crypt) *AbortTransaction {
 	if at == nil {
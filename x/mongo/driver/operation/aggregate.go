@@ -37,6 +37,7 @@ type Aggregate struct {
 	clock                    *session.ClusterClock
 	collection               string
 	monitor                  *event.CommandMonitor
+	interceptors             []event.CommandInterceptor
 	database                 string
 	deployment               driver.Deployment
 	readConcern              *readconcern.ReadConcern
@@ -99,6 +100,7 @@ func (a *Aggregate) Execute(ctx context.Context) error {
 		Client:                         a.session,
 		Clock:                          a.clock,
 		CommandMonitor:                 a.monitor,
+		Interceptors:                   a.interceptors,
 		Database:                       a.database,
 		Deployment:                     a.deployment,
 		ReadConcern:                    a.readConcern,
@@ -169,6 +171,10 @@ func (a *Aggregate) command(dst []byte, desc description.SelectedServer) ([]byte
 	return dst, nil
 }
 
+func (a *Aggregate) explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	return a.command(dst, desc)
+}
+
 // AllowDiskUse enables writing to temporary files. When true, aggregation stages can write to the dbPath/_tmp directory.
 func (a *Aggregate) AllowDiskUse(allowDiskUse bool) *Aggregate {
 	if a == nil {
@@ -289,6 +295,16 @@ func (a *Aggregate) CommandMonitor(monitor *event.CommandMonitor) *Aggregate {
 	return a
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (a *Aggregate) Interceptors(interceptors []event.CommandInterceptor) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.interceptors = interceptors
+	return a
+}
+
 // Database sets the database to run this operation against.
 func (a *Aggregate) Database(database string) *Aggregate {
 	if a == nil {
@@ -0,0 +1,560 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/bsontype"
+	"github.com/zhangdapeng520/zdpgo_mongo/event"
+	"github.com/zhangdapeng520/zdpgo_mongo/internal/driverutil"
+	"github.com/zhangdapeng520/zdpgo_mongo/internal/logger"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/description"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/writeconcern"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver/session"
+)
+
+// The bulkWrite command was introduced in MongoDB 8.0.
+const clientBulkWriteMinWireVersion = 25
+
+// clientBulkWriteMessageOverhead is the number of bytes of the maximum message size reserved for
+// the message header and the command document when splitting batches.
+const clientBulkWriteMessageOverhead = 1000
+
+// clientBulkWriteOpOverhead is the number of bytes an individual write in the "ops" document
+// sequence may exceed the maximum document size by.
+const clientBulkWriteOpOverhead = 16 * 1024
+
+// ClientBulkWriteOp is a single write in a ClientBulkWrite operation.
+type ClientBulkWriteOp struct {
+	// Namespace is the "<database>.<collection>" namespace targeted by the write.
+	Namespace string
+
+	// Kind is the kind of the write and must be "insert", "update" or "delete".
+	Kind string
+
+	// Fields contains the elements of the write other than its kind and namespace index, e.g.
+	// "document" for inserts or "filter" and "updateMods" for updates.
+	Fields bsoncore.Document
+}
+
+// ClientBulkWriteOpResult is the result of an individual write returned by the server.
+type ClientBulkWriteOpResult struct {
+	// The index of the write in the operations passed to NewClientBulkWrite.
+	Index int
+	OK    bool
+
+	// The number of documents affected by the write.
+	N int64
+
+	// The number of documents modified by an update write.
+	NModified int64
+
+	// The _id of a document upserted by an update write. It is the zero value if no document was
+	// upserted.
+	UpsertedID bsoncore.Value
+
+	// The error code, message and details for failed writes.
+	Code    int32
+	Message string
+	Details bsoncore.Document
+
+	// The original result document from the server response.
+	Raw bsoncore.Document
+}
+
+// ClientBulkWriteResult represents a bulkWrite result returned by the server, summed across
+// batches.
+type ClientBulkWriteResult struct {
+	NErrors   int64
+	NInserted int64
+	NUpserted int64
+	NMatched  int64
+	NModified int64
+	NDeleted  int64
+
+	// The individual results returned by the server. If errors only results were requested, this
+	// only contains the results of writes that failed.
+	Results []ClientBulkWriteOpResult
+
+	// The write concern errors returned for each batch.
+	WriteConcernErrors []driver.WriteConcernError
+
+	// The number of writes that were sent to the server.
+	Processed int
+}
+
+type clientBulkWriteBatch struct {
+	ops    []bsoncore.Document
+	nsInfo []bsoncore.Document
+}
+
+// ClientBulkWrite performs a bulkWrite operation that can target multiple namespaces.
+type ClientBulkWrite struct {
+	ops                      []ClientBulkWriteOp
+	errorsOnly               bool
+	ordered                  *bool
+	bypassDocumentValidation *bool
+	comment                  bsoncore.Value
+	let                      bsoncore.Document
+	session                  *session.Client
+	clock                    *session.ClusterClock
+	monitor                  *event.CommandMonitor
+	interceptors             []event.CommandInterceptor
+	crypt                    driver.Crypt
+	deployment               driver.Deployment
+	selector                 description.ServerSelector
+	writeConcern             *writeconcern.WriteConcern
+	retry                    *driver.RetryMode
+	serverAPI                *driver.ServerAPIOptions
+	timeout                  *time.Duration
+	logger                   *logger.Logger
+
+	next     int
+	batch    *clientBulkWriteBatch
+	response driver.CursorResponse
+	result   ClientBulkWriteResult
+}
+
+// NewClientBulkWrite constructs and returns a new ClientBulkWrite.
+func NewClientBulkWrite(ops []ClientBulkWriteOp) *ClientBulkWrite {
+	return &ClientBulkWrite{
+		ops: ops,
+	}
+}
+
+// Result returns the result of executing this operation.
+func (bw *ClientBulkWrite) Result() ClientBulkWriteResult { return bw.result }
+
+func (bw *ClientBulkWrite) processResponse(info driver.ResponseInfo) error {
+	counts := []struct {
+		key string
+		dst *int64
+	}{
+		{"nErrors", &bw.result.NErrors},
+		{"nInserted", &bw.result.NInserted},
+		{"nUpserted", &bw.result.NUpserted},
+		{"nMatched", &bw.result.NMatched},
+		{"nModified", &bw.result.NModified},
+		{"nDeleted", &bw.result.NDeleted},
+	}
+	for _, c := range counts {
+		if val, err := info.ServerResponse.LookupErr(c.key); err == nil {
+			n, ok := val.AsInt64OK()
+			if !ok {
+				return fmt.Errorf("response field '%s' is type int32 or int64, but received BSON type %s", c.key, val.Type)
+			}
+			*c.dst += n
+		}
+	}
+
+	var err error
+	bw.response, err = driver.NewCursorResponse(info)
+	return err
+}
+
+// Execute runs this operations and returns an error if the operation did not execute successfully. The writes are
+// split into as many batches as needed. Execute stops at the first batch that returns a top-level error, or at the
+// first batch with a failed write if the operation is ordered. Write concern errors do not stop execution and are
+// recorded in the result.
+func (bw *ClientBulkWrite) Execute(ctx context.Context) error {
+	if bw.deployment == nil {
+		return errors.New("the ClientBulkWrite operation must have a Deployment set before Execute can be called")
+	}
+	if len(bw.ops) == 0 {
+		return errors.New("the ClientBulkWrite operation must have at least one write")
+	}
+
+	for bw.next < len(bw.ops) {
+		bw.batch = nil
+		bw.response = driver.CursorResponse{}
+		nErrors := bw.result.NErrors
+
+		err := driver.Operation{
+			CommandFn:           bw.command,
+			DocumentSequencesFn: bw.documentSequences,
+			ProcessResponseFn:   bw.processResponse,
+			RetryMode:           bw.retry,
+			Type:                driver.Write,
+			Client:              bw.session,
+			Clock:               bw.clock,
+			CommandMonitor:      bw.monitor,
+			Interceptors:        bw.interceptors,
+			Crypt:               bw.crypt,
+			Database:            "admin",
+			Deployment:          bw.deployment,
+			Selector:            bw.selector,
+			WriteConcern:        bw.writeConcern,
+			ServerAPI:           bw.serverAPI,
+			Timeout:             bw.timeout,
+			Logger:              bw.logger,
+			Name:                driverutil.BulkWriteOp,
+		}.Execute(ctx)
+
+		var wce driver.WriteCommandError
+		switch {
+		case err == nil:
+		case errors.Is(err, driver.ErrUnacknowledgedWrite):
+		case errors.As(err, &wce) && wce.WriteConcernError != nil && len(wce.WriteErrors) == 0:
+			bw.result.WriteConcernErrors = append(bw.result.WriteConcernErrors, *wce.WriteConcernError)
+		default:
+			return err
+		}
+
+		start := bw.next
+		if bw.batch != nil {
+			bw.next += len(bw.batch.ops)
+		}
+		bw.result.Processed = bw.next
+
+		if err := bw.readResults(ctx, start); err != nil {
+			return err
+		}
+		if (bw.ordered == nil || *bw.ordered) && bw.result.NErrors > nErrors {
+			break
+		}
+	}
+	return nil
+}
+
+// readResults iterates the cursor of individual results returned for the batch starting at start.
+func (bw *ClientBulkWrite) readResults(ctx context.Context, start int) error {
+	if bw.response.FirstBatch == nil {
+		return nil
+	}
+
+	bc, err := driver.NewBatchCursor(bw.response, bw.session, bw.clock, driver.CursorOptions{
+		CommandMonitor: bw.monitor,
+		Interceptors:   bw.interceptors,
+		Crypt:          bw.crypt,
+		ServerAPI:      bw.serverAPI,
+	})
+	if err != nil {
+		return err
+	}
+	defer bc.Close(ctx)
+
+	for bc.Next(ctx) {
+		docs, err := bc.Batch().Documents()
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			res, err := buildClientBulkWriteOpResult(doc)
+			if err != nil {
+				return err
+			}
+			res.Index += start
+			bw.result.Results = append(bw.result.Results, res)
+		}
+	}
+	return bc.Err()
+}
+
+func buildClientBulkWriteOpResult(doc bsoncore.Document) (ClientBulkWriteOpResult, error) {
+	elements, err := doc.Elements()
+	if err != nil {
+		return ClientBulkWriteOpResult{}, err
+	}
+	res := ClientBulkWriteOpResult{Raw: doc}
+	for _, element := range elements {
+		switch element.Key() {
+		case "ok":
+			ok, valid := element.Value().AsInt64OK()
+			if !valid {
+				return res, fmt.Errorf("response field 'ok' is a numeric type, but received BSON type %s", element.Value().Type)
+			}
+			res.OK = ok == 1
+		case "idx":
+			idx, ok := element.Value().AsInt64OK()
+			if !ok {
+				return res, fmt.Errorf("response field 'idx' is type int32 or int64, but received BSON type %s", element.Value().Type)
+			}
+			res.Index = int(idx)
+		case "n":
+			res.N, _ = element.Value().AsInt64OK()
+		case "nModified":
+			res.NModified, _ = element.Value().AsInt64OK()
+		case "upserted":
+			if upserted, ok := element.Value().DocumentOK(); ok {
+				res.UpsertedID = upserted.Lookup("_id")
+			}
+		case "code":
+			res.Code, _ = element.Value().AsInt32OK()
+		case "errmsg":
+			res.Message, _ = element.Value().StringValueOK()
+		case "errInfo":
+			res.Details, _ = element.Value().DocumentOK()
+		}
+	}
+	return res, nil
+}
+
+func (bw *ClientBulkWrite) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	if desc.WireVersion == nil || !desc.WireVersion.Includes(clientBulkWriteMinWireVersion) {
+		return nil, errors.New("the 'bulkWrite' command requires a minimum server wire version of 25")
+	}
+	if err := bw.ensureBatch(desc); err != nil {
+		return nil, err
+	}
+
+	dst = bsoncore.AppendInt32Element(dst, "bulkWrite", 1)
+	dst = bsoncore.AppendBooleanElement(dst, "errorsOnly", bw.errorsOnly)
+	if bw.ordered != nil {
+		dst = bsoncore.AppendBooleanElement(dst, "ordered", *bw.ordered)
+	}
+	if bw.bypassDocumentValidation != nil {
+		dst = bsoncore.AppendBooleanElement(dst, "bypassDocumentValidation", *bw.bypassDocumentValidation)
+	}
+	if bw.comment.Type != bsontype.Type(0) {
+		dst = bsoncore.AppendValueElement(dst, "comment", bw.comment)
+	}
+	if bw.let != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "let", bw.let)
+	}
+	return dst, nil
+}
+
+func (bw *ClientBulkWrite) documentSequences(desc description.SelectedServer) ([]driver.DocumentSequence, error) {
+	if err := bw.ensureBatch(desc); err != nil {
+		return nil, err
+	}
+	return []driver.DocumentSequence{
+		{Identifier: "ops", Documents: bw.batch.ops},
+		{Identifier: "nsInfo", Documents: bw.batch.nsInfo},
+	}, nil
+}
+
+// ensureBatch splits the next batch of writes using the limits of the selected server. The batch is
+// kept for retries of the same command.
+func (bw *ClientBulkWrite) ensureBatch(desc description.SelectedServer) error {
+	if bw.batch != nil {
+		return nil
+	}
+
+	maxCount := int(desc.MaxBatchCount)
+	if maxCount <= 0 {
+		maxCount = 1
+	}
+	maxDocSize := int(desc.MaxDocumentSize) + clientBulkWriteOpOverhead
+	maxSize := int(desc.MaxMessageSize) - clientBulkWriteMessageOverhead - len(bw.let) - len(bw.comment.Data)
+
+	batch := &clientBulkWriteBatch{}
+	nsIndexes := make(map[string]int32)
+	size := 0
+	for i := bw.next; i < len(bw.ops) && len(batch.ops) < maxCount; i++ {
+		op := bw.ops[i]
+
+		var nsDoc bsoncore.Document
+		nsIndex, ok := nsIndexes[op.Namespace]
+		if !ok {
+			nsIndex = int32(len(batch.nsInfo))
+			nsDoc = bsoncore.NewDocumentBuilder().AppendString("ns", op.Namespace).Build()
+		}
+
+		idx, doc := bsoncore.AppendDocumentStart(nil)
+		doc = bsoncore.AppendInt32Element(doc, op.Kind, nsIndex)
+		if len(op.Fields) > 5 {
+			doc = append(doc, op.Fields[4:len(op.Fields)-1]...)
+		}
+		doc, _ = bsoncore.AppendDocumentEnd(doc, idx)
+
+		if len(doc) > maxDocSize {
+			return driver.ErrDocumentTooLarge
+		}
+		if len(batch.ops) > 0 && size+len(doc)+len(nsDoc) > maxSize {
+			break
+		}
+
+		if !ok {
+			nsIndexes[op.Namespace] = nsIndex
+			batch.nsInfo = append(batch.nsInfo, nsDoc)
+		}
+		batch.ops = append(batch.ops, doc)
+		size += len(doc) + len(nsDoc)
+	}
+
+	bw.batch = batch
+	return nil
+}
+
+// ErrorsOnly specifies whether the server should only return results for writes that failed.
+func (bw *ClientBulkWrite) ErrorsOnly(errorsOnly bool) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.errorsOnly = errorsOnly
+	return bw
+}
+
+// Ordered sets ordered. If true, when a write fails, the operation will return the error, when
+// false write failures do not stop execution of the operation.
+func (bw *ClientBulkWrite) Ordered(ordered bool) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.ordered = &ordered
+	return bw
+}
+
+// BypassDocumentValidation allows the operation to opt-out of document level validation.
+func (bw *ClientBulkWrite) BypassDocumentValidation(bypassDocumentValidation bool) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.bypassDocumentValidation = &bypassDocumentValidation
+	return bw
+}
+
+// Comment sets a value to help trace an operation.
+func (bw *ClientBulkWrite) Comment(comment bsoncore.Value) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.comment = comment
+	return bw
+}
+
+// Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
+func (bw *ClientBulkWrite) Let(let bsoncore.Document) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.let = let
+	return bw
+}
+
+// Session sets the session for this operation.
+func (bw *ClientBulkWrite) Session(session *session.Client) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.session = session
+	return bw
+}
+
+// ClusterClock sets the cluster clock for this operation.
+func (bw *ClientBulkWrite) ClusterClock(clock *session.ClusterClock) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.clock = clock
+	return bw
+}
+
+// CommandMonitor sets the monitor to use for APM events.
+func (bw *ClientBulkWrite) CommandMonitor(monitor *event.CommandMonitor) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.monitor = monitor
+	return bw
+}
+
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (bw *ClientBulkWrite) Interceptors(interceptors []event.CommandInterceptor) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.interceptors = interceptors
+	return bw
+}
+
+// Crypt sets the Crypt object to use for automatic encryption and decryption.
+func (bw *ClientBulkWrite) Crypt(crypt driver.Crypt) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.crypt = crypt
+	return bw
+}
+
+// Deployment sets the deployment to use for this operation.
+func (bw *ClientBulkWrite) Deployment(deployment driver.Deployment) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.deployment = deployment
+	return bw
+}
+
+// ServerSelector sets the selector used to retrieve a server.
+func (bw *ClientBulkWrite) ServerSelector(selector description.ServerSelector) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.selector = selector
+	return bw
+}
+
+// WriteConcern sets the write concern for this operation.
+func (bw *ClientBulkWrite) WriteConcern(writeConcern *writeconcern.WriteConcern) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.writeConcern = writeConcern
+	return bw
+}
+
+// Retry enables retryable mode for this operation. Retries are handled automatically in driver.Operation.Execute based
+// on how the operation is set.
+func (bw *ClientBulkWrite) Retry(retry driver.RetryMode) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.retry = &retry
+	return bw
+}
+
+// ServerAPI sets the server API version for this operation.
+func (bw *ClientBulkWrite) ServerAPI(serverAPI *driver.ServerAPIOptions) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.serverAPI = serverAPI
+	return bw
+}
+
+// Timeout sets the timeout for this operation.
+func (bw *ClientBulkWrite) Timeout(timeout *time.Duration) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.timeout = timeout
+	return bw
+}
+
+// Logger sets the logger for this operation.
+func (bw *ClientBulkWrite) Logger(logger *logger.Logger) *ClientBulkWrite {
+	if bw == nil {
+		bw = new(ClientBulkWrite)
+	}
+
+	bw.logger = logger
+	return bw
+}
@@ -0,0 +1,317 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/event"
+	"github.com/zhangdapeng520/zdpgo_mongo/internal/driverutil"
+	"github.com/zhangdapeng520/zdpgo_mongo/internal/logger"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/description"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/writeconcern"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver/session"
+)
+
+// CollMod performs a collMod operation.
+type CollMod struct {
+	collection                   string
+	validator                    bsoncore.Document
+	validationLevel              *string
+	validationAction             *string
+	changeStreamPreAndPostImages bsoncore.Document
+	index                        bsoncore.Document
+	expireAfterSeconds           *int64
+	viewOn                       *string
+	pipeline                     bsoncore.Document
+	session                      *session.Client
+	clock                        *session.ClusterClock
+	monitor                      *event.CommandMonitor
+	interceptors                 []event.CommandInterceptor
+	crypt                        driver.Crypt
+	database                     string
+	deployment                   driver.Deployment
+	selector                     description.ServerSelector
+	writeConcern                 *writeconcern.WriteConcern
+	serverAPI                    *driver.ServerAPIOptions
+	timeout                      *time.Duration
+	logger                       *logger.Logger
+	result                       bsoncore.Document
+}
+
+// NewCollMod constructs and returns a new CollMod.
+func NewCollMod(collection string) *CollMod {
+	return &CollMod{
+		collection: collection,
+	}
+}
+
+// Result returns the result of executing this operation.
+func (cm *CollMod) Result() bsoncore.Document { return cm.result }
+
+func (cm *CollMod) processResponse(info driver.ResponseInfo) error {
+	cm.result = info.ServerResponse
+	return nil
+}
+
+// Execute runs this operations and returns an error if the operation did not execute successfully.
+func (cm *CollMod) Execute(ctx context.Context) error {
+	if cm.deployment == nil {
+		return errors.New("the CollMod operation must have a Deployment set before Execute can be called")
+	}
+
+	return driver.Operation{
+		CommandFn:         cm.command,
+		ProcessResponseFn: cm.processResponse,
+		Client:            cm.session,
+		Clock:             cm.clock,
+		CommandMonitor:    cm.monitor,
+		Interceptors:      cm.interceptors,
+		Crypt:             cm.crypt,
+		Database:          cm.database,
+		Deployment:        cm.deployment,
+		Selector:          cm.selector,
+		WriteConcern:      cm.writeConcern,
+		ServerAPI:         cm.serverAPI,
+		Timeout:           cm.timeout,
+		Logger:            cm.logger,
+		Name:              driverutil.CollModOp,
+	}.Execute(ctx)
+}
+
+func (cm *CollMod) command(dst []byte, _ description.SelectedServer) ([]byte, error) {
+	dst = bsoncore.AppendStringElement(dst, "collMod", cm.collection)
+	if cm.validator != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "validator", cm.validator)
+	}
+	if cm.validationLevel != nil {
+		dst = bsoncore.AppendStringElement(dst, "validationLevel", *cm.validationLevel)
+	}
+	if cm.validationAction != nil {
+		dst = bsoncore.AppendStringElement(dst, "validationAction", *cm.validationAction)
+	}
+	if cm.changeStreamPreAndPostImages != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "changeStreamPreAndPostImages", cm.changeStreamPreAndPostImages)
+	}
+	if cm.index != nil {
+		dst = bsoncore.AppendDocumentElement(dst, "index", cm.index)
+	}
+	if cm.expireAfterSeconds != nil {
+		dst = bsoncore.AppendInt64Element(dst, "expireAfterSeconds", *cm.expireAfterSeconds)
+	}
+	if cm.viewOn != nil {
+		dst = bsoncore.AppendStringElement(dst, "viewOn", *cm.viewOn)
+	}
+	if cm.pipeline != nil {
+		dst = bsoncore.AppendArrayElement(dst, "pipeline", cm.pipeline)
+	}
+	return dst, nil
+}
+
+// Validator sets the validation rules for the collection.
+func (cm *CollMod) Validator(validator bsoncore.Document) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.validator = validator
+	return cm
+}
+
+// ValidationLevel sets how strictly validation rules are applied to existing documents.
+func (cm *CollMod) ValidationLevel(validationLevel string) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.validationLevel = &validationLevel
+	return cm
+}
+
+// ValidationAction sets what happens when a document fails validation.
+func (cm *CollMod) ValidationAction(validationAction string) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.validationAction = &validationAction
+	return cm
+}
+
+// ChangeStreamPreAndPostImages sets the changeStreamPreAndPostImages option for the collection.
+func (cm *CollMod) ChangeStreamPreAndPostImages(csppi bsoncore.Document) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.changeStreamPreAndPostImages = csppi
+	return cm
+}
+
+// Index sets the document identifying the index to modify and the changes to make to it.
+func (cm *CollMod) Index(index bsoncore.Document) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.index = index
+	return cm
+}
+
+// ExpireAfterSeconds sets the number of seconds after which time-series or clustered collection data expires.
+func (cm *CollMod) ExpireAfterSeconds(expireAfterSeconds int64) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.expireAfterSeconds = &expireAfterSeconds
+	return cm
+}
+
+// ViewOn sets the source collection or view of a view.
+func (cm *CollMod) ViewOn(viewOn string) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.viewOn = &viewOn
+	return cm
+}
+
+// Pipeline sets the aggregation pipeline of a view. The pipeline must be an array document.
+func (cm *CollMod) Pipeline(pipeline bsoncore.Document) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.pipeline = pipeline
+	return cm
+}
+
+// Session sets the session for this operation.
+func (cm *CollMod) Session(session *session.Client) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.session = session
+	return cm
+}
+
+// ClusterClock sets the cluster clock for this operation.
+func (cm *CollMod) ClusterClock(clock *session.ClusterClock) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.clock = clock
+	return cm
+}
+
+// CommandMonitor sets the monitor to use for APM events.
+func (cm *CollMod) CommandMonitor(monitor *event.CommandMonitor) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.monitor = monitor
+	return cm
+}
+
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (cm *CollMod) Interceptors(interceptors []event.CommandInterceptor) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.interceptors = interceptors
+	return cm
+}
+
+// Crypt sets the Crypt object to use for automatic encryption and decryption.
+func (cm *CollMod) Crypt(crypt driver.Crypt) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.crypt = crypt
+	return cm
+}
+
+// Database sets the database to run this operation against.
+func (cm *CollMod) Database(database string) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.database = database
+	return cm
+}
+
+// Deployment sets the deployment to use for this operation.
+func (cm *CollMod) Deployment(deployment driver.Deployment) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.deployment = deployment
+	return cm
+}
+
+// ServerSelector sets the selector used to retrieve a server.
+func (cm *CollMod) ServerSelector(selector description.ServerSelector) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.selector = selector
+	return cm
+}
+
+// WriteConcern sets the write concern for this operation.
+func (cm *CollMod) WriteConcern(writeConcern *writeconcern.WriteConcern) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.writeConcern = writeConcern
+	return cm
+}
+
+// ServerAPI sets the server API version for this operation.
+func (cm *CollMod) ServerAPI(serverAPI *driver.ServerAPIOptions) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.serverAPI = serverAPI
+	return cm
+}
+
+// Timeout sets the timeout for this operation.
+func (cm *CollMod) Timeout(timeout *time.Duration) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.timeout = timeout
+	return cm
+}
+
+// Logger sets the logger for this operation.
+func (cm *CollMod) Logger(logger *logger.Logger) *CollMod {
+	if cm == nil {
+		cm = new(CollMod)
+	}
+
+	cm.logger = logger
+	return cm
+}
@@ -30,6 +30,7 @@ type Command struct {
 	clock          *session.ClusterClock
 	session        *session.Client
 	monitor        *event.CommandMonitor
+	interceptors   []event.CommandInterceptor
 	resultResponse bsoncore.Document
 	resultCursor   *driver.BatchCursor
 	crypt          driver.Crypt
@@ -99,6 +100,7 @@ func (c *Command) Execute(ctx context.Context) error {
 		Client:         c.session,
 		Clock:          c.clock,
 		CommandMonitor: c.monitor,
+		Interceptors:   c.interceptors,
 		Database:       c.database,
 		Deployment:     c.deployment,
 		ReadPreference: c.readPreference,
@@ -140,6 +142,16 @@ func (c *Command) CommandMonitor(monitor *event.CommandMonitor) *Command {
 	return c
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (c *Command) Interceptors(interceptors []event.CommandInterceptor) *Command {
+	if c == nil {
+		c = new(Command)
+	}
+
+	c.interceptors = interceptors
+	return c
+}
+
 // Database sets the database to run this operation against.
 func (c *Command) Database(database string) *Command {
 	if c == nil {
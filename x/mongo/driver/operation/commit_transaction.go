@@ -27,6 +27,7 @@ type CommitTransaction struct {
 	session       *session.Client
 	clock         *session.ClusterClock
 	monitor       *event.CommandMonitor
+	interceptors  []event.CommandInterceptor
 	crypt         driver.Crypt
 	database      string
 	deployment    driver.Deployment
@@ -60,6 +61,7 @@ func (ct *CommitTransaction) Execute(ctx context.Context) error {
 		Client:            ct.session,
 		Clock:             ct.clock,
 		CommandMonitor:    ct.monitor,
+		Interceptors:      ct.interceptors,
 		Crypt:             ct.crypt,
 		Database:          ct.database,
 		Deployment:        ct.deployment,
@@ -131,6 +133,16 @@ func (ct *CommitTransaction) CommandMonitor(monitor *event.CommandMonitor) *Comm
 	return ct
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (ct *CommitTransaction) Interceptors(interceptors []event.CommandInterceptor) *CommitTransaction {
+	if ct == nil {
+		ct = new(CommitTransaction)
+	}
+
+	ct.interceptors = interceptors
+	return ct
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (ct *CommitTransaction) Crypt(crypt driver.Crypt) *CommitTransaction {
 	if ct == nil {
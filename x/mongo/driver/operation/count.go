@@ -32,6 +32,7 @@ type Count struct {
 	collection     string
 	comment        bsoncore.Value
 	monitor        *event.CommandMonitor
+	interceptors   []event.CommandInterceptor
 	crypt          driver.Crypt
 	database       string
 	deployment     driver.Deployment
@@ -118,6 +119,7 @@ func (c *Count) Execute(ctx context.Context) error {
 		Client:            c.session,
 		Clock:             c.clock,
 		CommandMonitor:    c.monitor,
+		Interceptors:      c.interceptors,
 		Crypt:             c.crypt,
 		Database:          c.database,
 		Deployment:        c.deployment,
@@ -151,6 +153,10 @@ func (c *Count) command(dst []byte, _ description.SelectedServer) ([]byte, error
 	return dst, nil
 }
 
+func (c *Count) explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	return c.command(dst, desc)
+}
+
 // MaxTime specifies the maximum amount of time to allow the query to run on the server.
 func (c *Count) MaxTime(maxTime *time.Duration) *Count {
 	if c == nil {
@@ -221,6 +227,16 @@ func (c *Count) CommandMonitor(monitor *event.CommandMonitor) *Count {
 	return c
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (c *Count) Interceptors(interceptors []event.CommandInterceptor) *Count {
+	if c == nil {
+		c = new(Count)
+	}
+
+	c.interceptors = interceptors
+	return c
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (c *Count) Crypt(crypt driver.Crypt) *Count {
 	if c == nil {
@@ -36,6 +36,7 @@ type Create struct {
 	session                      *session.Client
 	clock                        *session.ClusterClock
 	monitor                      *event.CommandMonitor
+	interceptors                 []event.CommandInterceptor
 	crypt                        driver.Crypt
 	database                     string
 	deployment                   driver.Deployment
@@ -71,6 +72,7 @@ func (c *Create) Execute(ctx context.Context) error {
 		Client:            c.session,
 		Clock:             c.clock,
 		CommandMonitor:    c.monitor,
+		Interceptors:      c.interceptors,
 		Crypt:             c.crypt,
 		Database:          c.database,
 		Deployment:        c.deployment,
@@ -300,6 +302,16 @@ func (c *Create) CommandMonitor(monitor *event.CommandMonitor) *Create {
 	return c
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (c *Create) Interceptors(interceptors []event.CommandInterceptor) *Create {
+	if c == nil {
+		c = new(Create)
+	}
+
+	c.interceptors = interceptors
+	return c
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (c *Create) Crypt(crypt driver.Crypt) *Create {
 	if c == nil {
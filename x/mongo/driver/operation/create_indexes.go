@@ -31,6 +31,7 @@ type CreateIndexes struct {
 	clock        *session.ClusterClock
 	collection   string
 	monitor      *event.CommandMonitor
+	interceptors []event.CommandInterceptor
 	crypt        driver.Crypt
 	database     string
 	deployment   driver.Deployment
@@ -110,6 +111,7 @@ func (ci *CreateIndexes) Execute(ctx context.Context) error {
 		Client:            ci.session,
 		Clock:             ci.clock,
 		CommandMonitor:    ci.monitor,
+		Interceptors:      ci.interceptors,
 		Crypt:             ci.crypt,
 		Database:          ci.database,
 		Deployment:        ci.deployment,
@@ -209,6 +211,16 @@ func (ci *CreateIndexes) CommandMonitor(monitor *event.CommandMonitor) *CreateIn
 	return ci
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (ci *CreateIndexes) Interceptors(interceptors []event.CommandInterceptor) *CreateIndexes {
+	if ci == nil {
+		ci = new(CreateIndexes)
+	}
+
+	ci.interceptors = interceptors
+	return ci
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (ci *CreateIndexes) Crypt(crypt driver.Crypt) *CreateIndexes {
 	if ci == nil {
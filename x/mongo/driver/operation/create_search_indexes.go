@@ -22,18 +22,19 @@ import (
 
 // CreateSearchIndexes performs a createSearchIndexes operation.
 type CreateSearchIndexes struct {
-	indexes    bsoncore.Document
-	session    *session.Client
-	clock      *session.ClusterClock
-	collection string
-	monitor    *event.CommandMonitor
-	crypt      driver.Crypt
-	database   string
-	deployment driver.Deployment
-	selector   description.ServerSelector
-	result     CreateSearchIndexesResult
-	serverAPI  *driver.ServerAPIOptions
-	timeout    *time.Duration
+	indexes      bsoncore.Document
+	session      *session.Client
+	clock        *session.ClusterClock
+	collection   string
+	monitor      *event.CommandMonitor
+	interceptors []event.CommandInterceptor
+	crypt        driver.Crypt
+	database     string
+	deployment   driver.Deployment
+	selector     description.ServerSelector
+	result       CreateSearchIndexesResult
+	serverAPI    *driver.ServerAPIOptions
+	timeout      *time.Duration
 }
 
 // CreateSearchIndexResult represents a single search index result in CreateSearchIndexesResult.
@@ -110,6 +111,7 @@ func (csi *CreateSearchIndexes) Execute(ctx context.Context) error {
 		Client:            csi.session,
 		Clock:             csi.clock,
 		CommandMonitor:    csi.monitor,
+		Interceptors:      csi.interceptors,
 		Crypt:             csi.crypt,
 		Database:          csi.database,
 		Deployment:        csi.deployment,
@@ -178,6 +180,16 @@ func (csi *CreateSearchIndexes) CommandMonitor(monitor *event.CommandMonitor) *C
 	return csi
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (csi *CreateSearchIndexes) Interceptors(interceptors []event.CommandInterceptor) *CreateSearchIndexes {
+	if csi == nil {
+		csi = new(CreateSearchIndexes)
+	}
+
+	csi.interceptors = interceptors
+	return csi
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (csi *CreateSearchIndexes) Crypt(crypt driver.Crypt) *CreateSearchIndexes {
 	if csi == nil {
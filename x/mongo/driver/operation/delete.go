@@ -32,6 +32,7 @@ type Delete struct {
 	clock        *session.ClusterClock
 	collection   string
 	monitor      *event.CommandMonitor
+	interceptors []event.CommandInterceptor
 	crypt        driver.Crypt
 	database     string
 	deployment   driver.Deployment
@@ -107,6 +108,7 @@ func (d *Delete) Execute(ctx context.Context) error {
 		Client:            d.session,
 		Clock:             d.clock,
 		CommandMonitor:    d.monitor,
+		Interceptors:      d.interceptors,
 		Crypt:             d.crypt,
 		Database:          d.database,
 		Deployment:        d.deployment,
@@ -142,6 +144,14 @@ func (d *Delete) command(dst []byte, desc description.SelectedServer) ([]byte, e
 	return dst, nil
 }
 
+func (d *Delete) explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	dst, err := d.command(dst, desc)
+	if err != nil {
+		return nil, err
+	}
+	return appendBatchArray(dst, "deletes", d.deletes), nil
+}
+
 // Deletes adds documents to this operation that will be used to determine what documents to delete when this operation
 // is executed. These documents should have the form {q: <query>, limit: <integer limit>, collation: <document>}. The
 // collation field is optional. If limit is 0, there will be no limit on the number of documents deleted.
@@ -215,6 +225,16 @@ func (d *Delete) CommandMonitor(monitor *event.CommandMonitor) *Delete {
 	return d
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (d *Delete) Interceptors(interceptors []event.CommandInterceptor) *Delete {
+	if d == nil {
+		d = new(Delete)
+	}
+
+	d.interceptors = interceptors
+	return d
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (d *Delete) Crypt(crypt driver.Crypt) *Delete {
 	if d == nil {
@@ -33,6 +33,7 @@ type Distinct struct {
 	collection     string
 	comment        bsoncore.Value
 	monitor        *event.CommandMonitor
+	interceptors   []event.CommandInterceptor
 	crypt          driver.Crypt
 	database       string
 	deployment     driver.Deployment
@@ -97,6 +98,7 @@ func (d *Distinct) Execute(ctx context.Context) error {
 		Client:            d.session,
 		Clock:             d.clock,
 		CommandMonitor:    d.monitor,
+		Interceptors:      d.interceptors,
 		Crypt:             d.crypt,
 		Database:          d.database,
 		Deployment:        d.deployment,
@@ -131,6 +133,10 @@ func (d *Distinct) command(dst []byte, desc description.SelectedServer) ([]byte,
 	return dst, nil
 }
 
+func (d *Distinct) explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	return d.command(dst, desc)
+}
+
 // Collation specifies a collation to be used.
 func (d *Distinct) Collation(collation bsoncore.Document) *Distinct {
 	if d == nil {
@@ -221,6 +227,16 @@ func (d *Distinct) CommandMonitor(monitor *event.CommandMonitor) *Distinct {
 	return d
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (d *Distinct) Interceptors(interceptors []event.CommandInterceptor) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.interceptors = interceptors
+	return d
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (d *Distinct) Crypt(crypt driver.Crypt) *Distinct {
 	if d == nil {
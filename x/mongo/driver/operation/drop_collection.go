@@ -27,6 +27,7 @@ type DropCollection struct {
 	clock        *session.ClusterClock
 	collection   string
 	monitor      *event.CommandMonitor
+	interceptors []event.CommandInterceptor
 	crypt        driver.Crypt
 	database     string
 	deployment   driver.Deployment
@@ -96,6 +97,7 @@ func (dc *DropCollection) Execute(ctx context.Context) error {
 		Client:            dc.session,
 		Clock:             dc.clock,
 		CommandMonitor:    dc.monitor,
+		Interceptors:      dc.interceptors,
 		Crypt:             dc.crypt,
 		Database:          dc.database,
 		Deployment:        dc.deployment,
@@ -153,6 +155,16 @@ func (dc *DropCollection) CommandMonitor(monitor *event.CommandMonitor) *DropCol
 	return dc
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (dc *DropCollection) Interceptors(interceptors []event.CommandInterceptor) *DropCollection {
+	if dc == nil {
+		dc = new(DropCollection)
+	}
+
+	dc.interceptors = interceptors
+	return dc
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (dc *DropCollection) Crypt(crypt driver.Crypt) *DropCollection {
 	if dc == nil {
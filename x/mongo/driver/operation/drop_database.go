@@ -24,6 +24,7 @@ type DropDatabase struct {
 	session      *session.Client
 	clock        *session.ClusterClock
 	monitor      *event.CommandMonitor
+	interceptors []event.CommandInterceptor
 	crypt        driver.Crypt
 	database     string
 	deployment   driver.Deployment
@@ -48,6 +49,7 @@ func (dd *DropDatabase) Execute(ctx context.Context) error {
 		Client:         dd.session,
 		Clock:          dd.clock,
 		CommandMonitor: dd.monitor,
+		Interceptors:   dd.interceptors,
 		Crypt:          dd.crypt,
 		Database:       dd.database,
 		Deployment:     dd.deployment,
@@ -95,6 +97,16 @@ func (dd *DropDatabase) CommandMonitor(monitor *event.CommandMonitor) *DropDatab
 	return dd
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (dd *DropDatabase) Interceptors(interceptors []event.CommandInterceptor) *DropDatabase {
+	if dd == nil {
+		dd = new(DropDatabase)
+	}
+
+	dd.interceptors = interceptors
+	return dd
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (dd *DropDatabase) Crypt(crypt driver.Crypt) *DropDatabase {
 	if dd == nil {
@@ -29,6 +29,7 @@ type DropIndexes struct {
 	clock        *session.ClusterClock
 	collection   string
 	monitor      *event.CommandMonitor
+	interceptors []event.CommandInterceptor
 	crypt        driver.Crypt
 	database     string
 	deployment   driver.Deployment
@@ -92,6 +93,7 @@ func (di *DropIndexes) Execute(ctx context.Context) error {
 		Client:            di.session,
 		Clock:             di.clock,
 		CommandMonitor:    di.monitor,
+		Interceptors:      di.interceptors,
 		Crypt:             di.crypt,
 		Database:          di.database,
 		Deployment:        di.deployment,
@@ -173,6 +175,16 @@ func (di *DropIndexes) CommandMonitor(monitor *event.CommandMonitor) *DropIndexe
 	return di
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (di *DropIndexes) Interceptors(interceptors []event.CommandInterceptor) *DropIndexes {
+	if di == nil {
+		di = new(DropIndexes)
+	}
+
+	di.interceptors = interceptors
+	return di
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (di *DropIndexes) Crypt(crypt driver.Crypt) *DropIndexes {
 	if di == nil {
@@ -21,18 +21,19 @@ import (
 
 // DropSearchIndex performs an dropSearchIndex operation.
 type DropSearchIndex struct {
-	index      string
-	session    *session.Client
-	clock      *session.ClusterClock
-	collection string
-	monitor    *event.CommandMonitor
-	crypt      driver.Crypt
-	database   string
-	deployment driver.Deployment
-	selector   description.ServerSelector
-	result     DropSearchIndexResult
-	serverAPI  *driver.ServerAPIOptions
-	timeout    *time.Duration
+	index        string
+	session      *session.Client
+	clock        *session.ClusterClock
+	collection   string
+	monitor      *event.CommandMonitor
+	interceptors []event.CommandInterceptor
+	crypt        driver.Crypt
+	database     string
+	deployment   driver.Deployment
+	selector     description.ServerSelector
+	result       DropSearchIndexResult
+	serverAPI    *driver.ServerAPIOptions
+	timeout      *time.Duration
 }
 
 // DropSearchIndexResult represents a dropSearchIndex result returned by the server.
@@ -87,6 +88,7 @@ func (dsi *DropSearchIndex) Execute(ctx context.Context) error {
 		Client:            dsi.session,
 		Clock:             dsi.clock,
 		CommandMonitor:    dsi.monitor,
+		Interceptors:      dsi.interceptors,
 		Crypt:             dsi.crypt,
 		Database:          dsi.database,
 		Deployment:        dsi.deployment,
@@ -153,6 +155,16 @@ func (dsi *DropSearchIndex) CommandMonitor(monitor *event.CommandMonitor) *DropS
 	return dsi
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (dsi *DropSearchIndex) Interceptors(interceptors []event.CommandInterceptor) *DropSearchIndex {
+	if dsi == nil {
+		dsi = new(DropSearchIndex)
+	}
+
+	dsi.interceptors = interceptors
+	return dsi
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (dsi *DropSearchIndex) Crypt(crypt driver.Crypt) *DropSearchIndex {
 	if dsi == nil {
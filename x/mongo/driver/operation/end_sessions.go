@@ -20,15 +20,16 @@ import (
 
 // EndSessions performs an endSessions operation.
 type EndSessions struct {
-	sessionIDs bsoncore.Document
-	session    *session.Client
-	clock      *session.ClusterClock
-	monitor    *event.CommandMonitor
-	crypt      driver.Crypt
-	database   string
-	deployment driver.Deployment
-	selector   description.ServerSelector
-	serverAPI  *driver.ServerAPIOptions
+	sessionIDs   bsoncore.Document
+	session      *session.Client
+	clock        *session.ClusterClock
+	monitor      *event.CommandMonitor
+	interceptors []event.CommandInterceptor
+	crypt        driver.Crypt
+	database     string
+	deployment   driver.Deployment
+	selector     description.ServerSelector
+	serverAPI    *driver.ServerAPIOptions
 }
 
 // NewEndSessions constructs and returns a new EndSessions.
@@ -55,6 +56,7 @@ func (es *EndSessions) Execute(ctx context.Context) error {
 		Client:            es.session,
 		Clock:             es.clock,
 		CommandMonitor:    es.monitor,
+		Interceptors:      es.interceptors,
 		Crypt:             es.crypt,
 		Database:          es.database,
 		Deployment:        es.deployment,
@@ -112,6 +114,16 @@ func (es *EndSessions) CommandMonitor(monitor *event.CommandMonitor) *EndSession
 	return es
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (es *EndSessions) Interceptors(interceptors []event.CommandInterceptor) *EndSessions {
+	if es == nil {
+		es = new(EndSessions)
+	}
+
+	es.interceptors = interceptors
+	return es
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (es *EndSessions) Crypt(crypt driver.Crypt) *EndSessions {
 	if es == nil {
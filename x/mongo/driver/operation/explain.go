@@ -0,0 +1,266 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/bson/bsontype"
+	"github.com/zhangdapeng520/zdpgo_mongo/event"
+	"github.com/zhangdapeng520/zdpgo_mongo/internal/driverutil"
+	"github.com/zhangdapeng520/zdpgo_mongo/internal/logger"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/description"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/readpref"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver/session"
+)
+
+// Explainable is implemented by operations whose command can be wrapped in an explain command. The Find, Aggregate,
+// Count, Distinct, Update and Delete operations implement this interface.
+type Explainable interface {
+	// explainCommand appends the elements of the command to be explained to dst. Unlike the command
+	// functions used to execute an operation, any batched documents must be included in the command.
+	explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error)
+}
+
+// Explain performs an explain operation.
+type Explain struct {
+	explainable    Explainable
+	verbosity      *string
+	comment        bsoncore.Value
+	session        *session.Client
+	clock          *session.ClusterClock
+	monitor        *event.CommandMonitor
+	interceptors   []event.CommandInterceptor
+	crypt          driver.Crypt
+	database       string
+	deployment     driver.Deployment
+	readPreference *readpref.ReadPref
+	selector       description.ServerSelector
+	serverAPI      *driver.ServerAPIOptions
+	timeout        *time.Duration
+	logger         *logger.Logger
+	result         bsoncore.Document
+}
+
+// NewExplain constructs and returns a new Explain that explains the command of the given operation.
+func NewExplain(explainable Explainable) *Explain {
+	return &Explain{
+		explainable: explainable,
+	}
+}
+
+// Result returns the result of executing this operation.
+func (e *Explain) Result() bsoncore.Document { return e.result }
+
+func (e *Explain) processResponse(info driver.ResponseInfo) error {
+	e.result = info.ServerResponse
+	return nil
+}
+
+// Execute runs this operations and returns an error if the operation did not execute successfully.
+func (e *Explain) Execute(ctx context.Context) error {
+	if e.deployment == nil {
+		return errors.New("the Explain operation must have a Deployment set before Execute can be called")
+	}
+	if e.explainable == nil {
+		return errors.New("the Explain operation must have an operation to explain set before Execute can be called")
+	}
+
+	return driver.Operation{
+		CommandFn:         e.command,
+		ProcessResponseFn: e.processResponse,
+		Client:            e.session,
+		Clock:             e.clock,
+		CommandMonitor:    e.monitor,
+		Interceptors:      e.interceptors,
+		Crypt:             e.crypt,
+		Database:          e.database,
+		Deployment:        e.deployment,
+		ReadPreference:    e.readPreference,
+		Selector:          e.selector,
+		ServerAPI:         e.serverAPI,
+		Timeout:           e.timeout,
+		Logger:            e.logger,
+		Name:              driverutil.ExplainOp,
+	}.Execute(ctx)
+}
+
+func (e *Explain) command(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	idx, dst := bsoncore.AppendDocumentElementStart(dst, "explain")
+	dst, err := e.explainable.explainCommand(dst, desc)
+	if err != nil {
+		return nil, err
+	}
+	dst, err = bsoncore.AppendDocumentEnd(dst, idx)
+	if err != nil {
+		return nil, err
+	}
+	if e.verbosity != nil {
+		dst = bsoncore.AppendStringElement(dst, "verbosity", *e.verbosity)
+	}
+	if e.comment.Type != bsontype.Type(0) {
+		dst = bsoncore.AppendValueElement(dst, "comment", e.comment)
+	}
+	return dst, nil
+}
+
+// appendBatchArray appends the given documents to dst as an array element named key. It is used by write operations
+// to inline their batched documents into the command being explained.
+func appendBatchArray(dst []byte, key string, docs []bsoncore.Document) []byte {
+	aidx, dst := bsoncore.AppendArrayElementStart(dst, key)
+	for i, doc := range docs {
+		dst = bsoncore.AppendDocumentElement(dst, strconv.Itoa(i), doc)
+	}
+	dst, _ = bsoncore.AppendArrayEnd(dst, aidx)
+	return dst
+}
+
+// Verbosity sets the verbosity mode of the explain command. Valid values are "queryPlanner", "executionStats" and
+// "allPlansExecution".
+func (e *Explain) Verbosity(verbosity string) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.verbosity = &verbosity
+	return e
+}
+
+// Comment sets a value to help trace an operation.
+func (e *Explain) Comment(comment bsoncore.Value) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.comment = comment
+	return e
+}
+
+// Session sets the session for this operation.
+func (e *Explain) Session(session *session.Client) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.session = session
+	return e
+}
+
+// ClusterClock sets the cluster clock for this operation.
+func (e *Explain) ClusterClock(clock *session.ClusterClock) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.clock = clock
+	return e
+}
+
+// CommandMonitor sets the monitor to use for APM events.
+func (e *Explain) CommandMonitor(monitor *event.CommandMonitor) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.monitor = monitor
+	return e
+}
+
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (e *Explain) Interceptors(interceptors []event.CommandInterceptor) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.interceptors = interceptors
+	return e
+}
+
+// Crypt sets the Crypt object to use for automatic encryption and decryption.
+func (e *Explain) Crypt(crypt driver.Crypt) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.crypt = crypt
+	return e
+}
+
+// Database sets the database to run this operation against.
+func (e *Explain) Database(database string) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.database = database
+	return e
+}
+
+// Deployment sets the deployment to use for this operation.
+func (e *Explain) Deployment(deployment driver.Deployment) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.deployment = deployment
+	return e
+}
+
+// ReadPreference set the read preference used with this operation.
+func (e *Explain) ReadPreference(readPreference *readpref.ReadPref) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.readPreference = readPreference
+	return e
+}
+
+// ServerSelector sets the selector used to retrieve a server.
+func (e *Explain) ServerSelector(selector description.ServerSelector) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.selector = selector
+	return e
+}
+
+// ServerAPI sets the server API version for this operation.
+func (e *Explain) ServerAPI(serverAPI *driver.ServerAPIOptions) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.serverAPI = serverAPI
+	return e
+}
+
+// Timeout sets the timeout for this operation.
+func (e *Explain) Timeout(timeout *time.Duration) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.timeout = timeout
+	return e
+}
+
+// Logger sets the logger for this operation.
+func (e *Explain) Logger(logger *logger.Logger) *Explain {
+	if e == nil {
+		e = new(Explain)
+	}
+
+	e.logger = logger
+	return e
+}
@@ -52,6 +52,7 @@ type Find struct {
 	clock               *session.ClusterClock
 	collection          string
 	monitor             *event.CommandMonitor
+	interceptors        []event.CommandInterceptor
 	crypt               driver.Crypt
 	database            string
 	deployment          driver.Deployment
@@ -99,6 +100,7 @@ func (f *Find) Execute(ctx context.Context) error {
 		Client:            f.session,
 		Clock:             f.clock,
 		CommandMonitor:    f.monitor,
+		Interceptors:      f.interceptors,
 		Crypt:             f.crypt,
 		Database:          f.database,
 		Deployment:        f.deployment,
@@ -193,6 +195,10 @@ func (f *Find) command(dst []byte, desc description.SelectedServer) ([]byte, err
 	return dst, nil
 }
 
+func (f *Find) explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	return f.command(dst, desc)
+}
+
 // AllowDiskUse when true allows temporary data to be written to disk during the find command."
 func (f *Find) AllowDiskUse(allowDiskUse bool) *Find {
 	if f == nil {
@@ -463,6 +469,16 @@ func (f *Find) CommandMonitor(monitor *event.CommandMonitor) *Find {
 	return f
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (f *Find) Interceptors(interceptors []event.CommandInterceptor) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.interceptors = interceptors
+	return f
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (f *Find) Crypt(crypt driver.Crypt) *Find {
 	if f == nil {
@@ -41,6 +41,7 @@ type FindAndModify struct {
 	clock                    *session.ClusterClock
 	collection               string
 	monitor                  *event.CommandMonitor
+	interceptors             []event.CommandInterceptor
 	database                 string
 	deployment               driver.Deployment
 	selector                 description.ServerSelector
@@ -136,6 +137,7 @@ func (fam *FindAndModify) Execute(ctx context.Context) error {
 		Client:         fam.session,
 		Clock:          fam.clock,
 		CommandMonitor: fam.monitor,
+		Interceptors:   fam.interceptors,
 		Database:       fam.database,
 		Deployment:     fam.deployment,
 		MaxTime:        fam.maxTime,
@@ -376,6 +378,16 @@ func (fam *FindAndModify) CommandMonitor(monitor *event.CommandMonitor) *FindAnd
 	return fam
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (fam *FindAndModify) Interceptors(interceptors []event.CommandInterceptor) *FindAndModify {
+	if fam == nil {
+		fam = new(FindAndModify)
+	}
+
+	fam.interceptors = interceptors
+	return fam
+}
+
 // Database sets the database to run this operation against.
 func (fam *FindAndModify) Database(database string) *FindAndModify {
 	if fam == nil {
@@ -33,6 +33,7 @@ type Insert struct {
 	clock                    *session.ClusterClock
 	collection               string
 	monitor                  *event.CommandMonitor
+	interceptors             []event.CommandInterceptor
 	crypt                    driver.Crypt
 	database                 string
 	deployment               driver.Deployment
@@ -106,6 +107,7 @@ func (i *Insert) Execute(ctx context.Context) error {
 		Client:            i.session,
 		Clock:             i.clock,
 		CommandMonitor:    i.monitor,
+		Interceptors:      i.interceptors,
 		Crypt:             i.crypt,
 		Database:          i.database,
 		Deployment:        i.deployment,
@@ -216,6 +218,16 @@ func (i *Insert) CommandMonitor(monitor *event.CommandMonitor) *Insert {
 	return i
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (i *Insert) Interceptors(interceptors []event.CommandInterceptor) *Insert {
+	if i == nil {
+		i = new(Insert)
+	}
+
+	i.interceptors = interceptors
+	return i
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (i *Insert) Crypt(crypt driver.Crypt) *Insert {
 	if i == nil {
@@ -30,6 +30,7 @@ type ListDatabases struct {
 	session             *session.Client
 	clock               *session.ClusterClock
 	monitor             *event.CommandMonitor
+	interceptors        []event.CommandInterceptor
 	database            string
 	deployment          driver.Deployment
 	readPreference      *readpref.ReadPref
@@ -155,6 +156,7 @@ func (ld *ListDatabases) Execute(ctx context.Context) error {
 		Client:         ld.session,
 		Clock:          ld.clock,
 		CommandMonitor: ld.monitor,
+		Interceptors:   ld.interceptors,
 		Database:       ld.database,
 		Deployment:     ld.deployment,
 		ReadPreference: ld.readPreference,
@@ -247,6 +249,16 @@ func (ld *ListDatabases) CommandMonitor(monitor *event.CommandMonitor) *ListData
 	return ld
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (ld *ListDatabases) Interceptors(interceptors []event.CommandInterceptor) *ListDatabases {
+	if ld == nil {
+		ld = new(ListDatabases)
+	}
+
+	ld.interceptors = interceptors
+	return ld
+}
+
 // Database sets the database to run this operation against.
 func (ld *ListDatabases) Database(database string) *ListDatabases {
 	if ld == nil {
@@ -28,6 +28,7 @@ type ListCollections struct {
 	session               *session.Client
 	clock                 *session.ClusterClock
 	monitor               *event.CommandMonitor
+	interceptors          []event.CommandInterceptor
 	crypt                 driver.Crypt
 	database              string
 	deployment            driver.Deployment
@@ -74,6 +75,7 @@ func (lc *ListCollections) Execute(ctx context.Context) error {
 		Client:            lc.session,
 		Clock:             lc.clock,
 		CommandMonitor:    lc.monitor,
+		Interceptors:      lc.interceptors,
 		Crypt:             lc.crypt,
 		Database:          lc.database,
 		Deployment:        lc.deployment,
@@ -169,6 +171,16 @@ func (lc *ListCollections) CommandMonitor(monitor *event.CommandMonitor) *ListCo
 	return lc
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (lc *ListCollections) Interceptors(interceptors []event.CommandInterceptor) *ListCollections {
+	if lc == nil {
+		lc = new(ListCollections)
+	}
+
+	lc.interceptors = interceptors
+	return lc
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (lc *ListCollections) Crypt(crypt driver.Crypt) *ListCollections {
 	if lc == nil {
@@ -21,19 +21,20 @@ import (
 
 // ListIndexes performs a listIndexes operation.
 type ListIndexes struct {
-	batchSize  *int32
-	maxTime    *time.Duration
-	session    *session.Client
-	clock      *session.ClusterClock
-	collection string
-	monitor    *event.CommandMonitor
-	database   string
-	deployment driver.Deployment
-	selector   description.ServerSelector
-	retry      *driver.RetryMode
-	crypt      driver.Crypt
-	serverAPI  *driver.ServerAPIOptions
-	timeout    *time.Duration
+	batchSize    *int32
+	maxTime      *time.Duration
+	session      *session.Client
+	clock        *session.ClusterClock
+	collection   string
+	monitor      *event.CommandMonitor
+	interceptors []event.CommandInterceptor
+	database     string
+	deployment   driver.Deployment
+	selector     description.ServerSelector
+	retry        *driver.RetryMode
+	crypt        driver.Crypt
+	serverAPI    *driver.ServerAPIOptions
+	timeout      *time.Duration
 
 	result driver.CursorResponse
 }
@@ -74,6 +75,7 @@ func (li *ListIndexes) Execute(ctx context.Context) error {
 		Client:         li.session,
 		Clock:          li.clock,
 		CommandMonitor: li.monitor,
+		Interceptors:   li.interceptors,
 		Database:       li.database,
 		Deployment:     li.deployment,
 		MaxTime:        li.maxTime,
@@ -163,6 +165,16 @@ func (li *ListIndexes) CommandMonitor(monitor *event.CommandMonitor) *ListIndexe
 	return li
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (li *ListIndexes) Interceptors(interceptors []event.CommandInterceptor) *ListIndexes {
+	if li == nil {
+		li = new(ListIndexes)
+	}
+
+	li.interceptors = interceptors
+	return li
+}
+
 // Database sets the database to run this operation against.
 func (li *ListIndexes) Database(database string) *ListIndexes {
 	if li == nil {
@@ -34,6 +34,7 @@ type Update struct {
 	clock                    *session.ClusterClock
 	collection               string
 	monitor                  *event.CommandMonitor
+	interceptors             []event.CommandInterceptor
 	database                 string
 	deployment               driver.Deployment
 	hint                     *bool
@@ -158,6 +159,7 @@ func (u *Update) Execute(ctx context.Context) error {
 		Client:            u.session,
 		Clock:             u.clock,
 		CommandMonitor:    u.monitor,
+		Interceptors:      u.interceptors,
 		Database:          u.database,
 		Deployment:        u.deployment,
 		Selector:          u.selector,
@@ -206,6 +208,14 @@ func (u *Update) command(dst []byte, desc description.SelectedServer) ([]byte, e
 	return dst, nil
 }
 
+func (u *Update) explainCommand(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	dst, err := u.command(dst, desc)
+	if err != nil {
+		return nil, err
+	}
+	return appendBatchArray(dst, "updates", u.updates), nil
+}
+
 // BypassDocumentValidation allows the operation to opt-out of document level validation. Valid
 // for server versions >= 3.2. For servers < 3.2, this setting is ignored.
 func (u *Update) BypassDocumentValidation(bypassDocumentValidation bool) *Update {
@@ -303,6 +313,16 @@ func (u *Update) CommandMonitor(monitor *event.CommandMonitor) *Update {
 	return u
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (u *Update) Interceptors(interceptors []event.CommandInterceptor) *Update {
+	if u == nil {
+		u = new(Update)
+	}
+
+	u.interceptors = interceptors
+	return u
+}
+
 // Comment sets a value to help trace an operation.
 func (u *Update) Comment(comment bsoncore.Value) *Update {
 	if u == nil {
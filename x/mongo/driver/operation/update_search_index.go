@@ -21,19 +21,20 @@ import (
 
 // UpdateSearchIndex performs a updateSearchIndex operation.
 type UpdateSearchIndex struct {
-	index      string
-	definition bsoncore.Document
-	session    *session.Client
-	clock      *session.ClusterClock
-	collection string
-	monitor    *event.CommandMonitor
-	crypt      driver.Crypt
-	database   string
-	deployment driver.Deployment
-	selector   description.ServerSelector
-	result     UpdateSearchIndexResult
-	serverAPI  *driver.ServerAPIOptions
-	timeout    *time.Duration
+	index        string
+	definition   bsoncore.Document
+	session      *session.Client
+	clock        *session.ClusterClock
+	collection   string
+	monitor      *event.CommandMonitor
+	interceptors []event.CommandInterceptor
+	crypt        driver.Crypt
+	database     string
+	deployment   driver.Deployment
+	selector     description.ServerSelector
+	result       UpdateSearchIndexResult
+	serverAPI    *driver.ServerAPIOptions
+	timeout      *time.Duration
 }
 
 // UpdateSearchIndexResult represents a single index in the updateSearchIndexResult result.
@@ -89,6 +90,7 @@ func (usi *UpdateSearchIndex) Execute(ctx context.Context) error {
 		Client:            usi.session,
 		Clock:             usi.clock,
 		CommandMonitor:    usi.monitor,
+		Interceptors:      usi.interceptors,
 		Crypt:             usi.crypt,
 		Database:          usi.database,
 		Deployment:        usi.deployment,
@@ -166,6 +168,16 @@ func (usi *UpdateSearchIndex) CommandMonitor(monitor *event.CommandMonitor) *Upd
 	return usi
 }
 
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (usi *UpdateSearchIndex) Interceptors(interceptors []event.CommandInterceptor) *UpdateSearchIndex {
+	if usi == nil {
+		usi = new(UpdateSearchIndex)
+	}
+
+	usi.interceptors = interceptors
+	return usi
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (usi *UpdateSearchIndex) Crypt(crypt driver.Crypt) *UpdateSearchIndex {
 	if usi == nil {
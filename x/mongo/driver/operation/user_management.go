@@ -0,0 +1,224 @@
+// Copyright (C) MongoDB, Inc. 2024-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zhangdapeng520/zdpgo_mongo/event"
+	"github.com/zhangdapeng520/zdpgo_mongo/internal/logger"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/description"
+	"github.com/zhangdapeng520/zdpgo_mongo/mongo/writeconcern"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/bsonx/bsoncore"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver"
+	"github.com/zhangdapeng520/zdpgo_mongo/x/mongo/driver/session"
+)
+
+// UserManagement performs one of the user or role management commands, e.g. createUser, grantRolesToUser or
+// rolesInfo. The commands share the same shape: the command name is followed by the name of the user or role the
+// command applies to and a set of command-specific fields.
+type UserManagement struct {
+	commandName  string
+	target       bsoncore.Value
+	fields       bsoncore.Document
+	session      *session.Client
+	clock        *session.ClusterClock
+	monitor      *event.CommandMonitor
+	interceptors []event.CommandInterceptor
+	crypt        driver.Crypt
+	database     string
+	deployment   driver.Deployment
+	selector     description.ServerSelector
+	writeConcern *writeconcern.WriteConcern
+	serverAPI    *driver.ServerAPIOptions
+	timeout      *time.Duration
+	logger       *logger.Logger
+	result       bsoncore.Document
+}
+
+// NewUserManagement constructs and returns a new UserManagement. The commandName is the name of the user or role
+// management command to run, target is the value of the command name element (usually the user or role name), and
+// fields contains the remaining command-specific elements. The fields parameter may be nil.
+func NewUserManagement(commandName string, target bsoncore.Value, fields bsoncore.Document) *UserManagement {
+	return &UserManagement{
+		commandName: commandName,
+		target:      target,
+		fields:      fields,
+	}
+}
+
+// Result returns the result of executing this operation.
+func (um *UserManagement) Result() bsoncore.Document { return um.result }
+
+func (um *UserManagement) processResponse(info driver.ResponseInfo) error {
+	um.result = info.ServerResponse
+	return nil
+}
+
+// Execute runs this operations and returns an error if the operation did not execute successfully.
+func (um *UserManagement) Execute(ctx context.Context) error {
+	if um.deployment == nil {
+		return errors.New("the UserManagement operation must have a Deployment set before Execute can be called")
+	}
+	if um.commandName == "" {
+		return errors.New("the UserManagement operation must have a command name set before Execute can be called")
+	}
+
+	return driver.Operation{
+		CommandFn:         um.command,
+		ProcessResponseFn: um.processResponse,
+		Client:            um.session,
+		Clock:             um.clock,
+		CommandMonitor:    um.monitor,
+		Interceptors:      um.interceptors,
+		Crypt:             um.crypt,
+		Database:          um.database,
+		Deployment:        um.deployment,
+		Selector:          um.selector,
+		WriteConcern:      um.writeConcern,
+		ServerAPI:         um.serverAPI,
+		Timeout:           um.timeout,
+		Logger:            um.logger,
+		Name:              um.commandName,
+	}.Execute(ctx)
+}
+
+func (um *UserManagement) command(dst []byte, _ description.SelectedServer) ([]byte, error) {
+	dst = bsoncore.AppendValueElement(dst, um.commandName, um.target)
+	if len(um.fields) > 0 {
+		elems, err := um.fields.Elements()
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range elems {
+			dst = append(dst, elem...)
+		}
+	}
+	return dst, nil
+}
+
+// Session sets the session for this operation.
+func (um *UserManagement) Session(session *session.Client) *UserManagement {
+	if um == nil {
+		um = new(UserManagement)
+	}
+
+	um.session = session
+	return um
+}
+
+// ClusterClock sets the cluster clock for this operation.
+func (um *UserManagement) ClusterClock(clock *session.ClusterClock) *UserManagement {
+	if um == nil {
+		um = new(UserManagement)
+	}
+
+	um.clock = clock
+	return um
+}
+
+// CommandMonitor sets the monitor to use for APM events.
+func (um *UserManagement) CommandMonitor(monitor *event.CommandMonitor) *UserManagement {
+	if um == nil {
+		um = new(UserManagement)
+	}
+
+	um.monitor = monitor
+	return um
+}
+
+// Interceptors sets the interceptors that wrap the execution of the operation.
+func (um *UserManagement) Interceptors(interceptors []event.CommandInterceptor) *UserManagement {
+	if um == nil {
+		um = new(UserManagement)
+	}
+
+	um.interceptors = interceptors
+	return um
+}
+
+// Crypt sets the Crypt object to use for automatic encryption and decryption.
+func (um *UserManagement) Crypt(crypt driver.Crypt) *UserManagement {
+	if um == nil {
+		um = new(UserManagement)
+	}
+
+	um.crypt = crypt
+	return um
+}
+
+// Database sets the database to run this operation against.
+func (um *UserManagement) Database(database string) *UserManagement {
+	if um == nil {
+		um = new(UserManagement)
+	}
+
+	um.database = database
+	return um
+}
+
+// Deployment sets the deployment to use for this operation.
+func (um *UserManagement) Deployment(deployment driver.Deployment) *UserManagement {
+	if um == nil {
+		um = new(UserManagement)
+	}
+
+	um.deployment = deployment
+	return um
+}
+
+// ServerSelector sets the selector used to retrieve a server.
+func (um *UserManagement) ServerSelector(selector description.ServerSelector) *UserManagement {
+	if um == nil {
+		um = new(UserManagement)
+	}
+
+	um.selector = selector
+	return um
+}
+
+// WriteConcern sets the write concern for this operation.
+func (um *UserManagement) WriteConcern(writeConcern *writeconcern.WriteConcern) *UserManagement {
+	if um == nil {
+		um = new(UserManagement)
+	}
+
+	um.writeConcern = writeConcern
+	return um
+}
+
+// ServerAPI sets the server API version for this operation.
+func (um *UserManagement) ServerAPI(serverAPI *driver.ServerAPIOptions) *UserManagement {
+	if um == nil {
+		um = new(UserManagement)
+	}
+
+	um.serverAPI = serverAPI
+	return um
+}
+
+// Timeout sets the timeout for this operation.
+func (um *UserManagement) Timeout(timeout *time.Duration) *UserManagement {
+	if um == nil {
+		um = new(UserManagement)
+	}
+
+	um.timeout = timeout
+	return um
+}
+
+// Logger sets the logger for this operation.
+func (um *UserManagement) Logger(logger *logger.Logger) *UserManagement {
+	if um == nil {
+		um = new(UserManagement)
+	}
+
+	um.logger = logger
+	return um
+}